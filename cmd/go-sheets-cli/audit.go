@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+
+	courseapi "go-sheets/courseapi"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	HistoryCorrectUsageMsg = "Usage: history [<course_name>]"
+	NoHistoryMsg           = "No history recorded yet."
+
+	UnsuccessfulAuditMsg       = "Unable to successfully record history entry"
+	UnsuccessfulHistoryLoadMsg = "Unable to successfully load history"
+
+	historySheetName = "History"
+	historyShowLimit = 20
+	actorEnvKey      = "GOSHEETS_ACTOR"
+)
+
+// Names whoever is making changes: GOSHEETS_ACTOR if set, otherwise the OS user
+func currentActor() string {
+	if actor, exists := os.LookupEnv(actorEnvKey); exists && actor != "" {
+		return actor
+	}
+
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return "unknown"
+}
+
+// Appends a history entry for a change that has already been persisted, and runs hooks for it.
+// Failures are only logged, since the change itself went through.
+func auditChange(command string, before *CourseItem, after *CourseItem) {
+	emitChangeEvents(command, before, after)
+
+	entry := courseapi.NewHistoryEntry(currentActor(), command, before, after)
+	entry.Term = sheetName
+
+	err := appendHistoryEntry(srv, entry)
+	if err != nil {
+		log.Printf(UnsuccessfulAuditMsg+" for `%s`: %v", command, err)
+	}
+}
+
+func appendHistoryEntry(srv *sheets.Service, entry courseapi.HistoryEntry) error {
+	err := ensureSheetTab(srv, historySheetName, courseapi.HistoryHeader, false)
+	if err != nil {
+		return err
+	}
+
+	row, err := entry.Row()
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %v", err)
+	}
+
+	writeRange := courseapi.TabRange(historySheetName, "A:G")
+	_, err = srv.Spreadsheets.Values.Append(spreadsheetId, writeRange, &sheets.ValueRange{
+		Values: [][]interface{}{row},
+	}).ValueInputOption("RAW").InsertDataOption("INSERT_ROWS").Do()
+	if err != nil {
+		return fmt.Errorf("failed to append history entry: %v", err)
+	}
+
+	return nil
+}
+
+// Reads every history entry, oldest first, skipping any rows that can't be parsed
+func loadHistoryEntries(srv *sheets.Service) ([]courseapi.HistoryEntry, error) {
+	err := ensureSheetTab(srv, historySheetName, courseapi.HistoryHeader, false)
+	if err != nil {
+		return nil, err
+	}
+
+	readRange := courseapi.TabRange(historySheetName, "A2:G")
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetId, readRange).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history: %v", err)
+	}
+
+	var entries []courseapi.HistoryEntry
+	for i, row := range resp.Values {
+		entry, err := courseapi.HistoryEntryFromRow(row)
+		if err != nil {
+			log.Printf("Skipping history row %d: %v", i+2, err)
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Prints the most recent history entries of the current term, optionally only those for one course
+func showHistory(courseName string) {
+	entries, err := loadHistoryEntries(srv)
+	if err != nil {
+		log.Printf(UnsuccessfulHistoryLoadMsg+": %v", err)
+
+		fmt.Println("Unable to successfully load history")
+		return
+	}
+
+	var filtered []courseapi.HistoryEntry
+	for _, entry := range entries {
+		if inCurrentTerm(entry.Term) && (courseName == "" || entry.Course == courseName) {
+			filtered = append(filtered, entry)
+		}
+	}
+	entries = filtered
+
+	if len(entries) == 0 {
+		fmt.Println(NoHistoryMsg)
+		return
+	}
+
+	if len(entries) > historyShowLimit {
+		fmt.Printf("(showing the %d most recent of %d entries)\n", historyShowLimit, len(entries))
+		entries = entries[len(entries)-historyShowLimit:]
+	}
+
+	for _, entry := range entries {
+		fmt.Printf("%s\n\n", entry.String())
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	LoginCorrectUsageMsg  = "Usage: login"
+	LogoutCorrectUsageMsg = "Usage: logout"
+	LoginNotNeededErrMsg  = "logging in is only needed when auth is oauth, this profile uses a service account"
+
+	UnsuccessfulLoginMsg  = "Unable to successfully log in for reason"
+	UnsuccessfulLogoutMsg = "Unable to successfully log out for reason"
+
+	defaultOAuthClientFile = "oauth-client.json"
+
+	// How long to wait for the consent page to redirect back before giving up
+	loginTimeout  = 5 * time.Minute
+	revokeTimeout = 10 * time.Second
+)
+
+var (
+	oauthScopes = []string{sheets.SpreadsheetsScope, drive.DriveFileScope}
+
+	// Supplies the logged in user's token when auth is oauth, and is nil with a service account
+	tokenSource *courseapi.CachedTokenSource
+)
+
+// Prepares the credentials the sheets service authenticates with
+func initAuth() error {
+	if settings.Auth != courseapi.OAuthAuth {
+		return nil
+	}
+
+	config, err := courseapi.LoadOAuthClient(settings.Credentials, oauthScopes...)
+	if err != nil {
+		return err
+	}
+
+	tokenSource = &courseapi.CachedTokenSource{Config: config, Cache: courseapi.TokenCache{Path: settings.TokenFile}}
+	return nil
+}
+
+func authOption() option.ClientOption {
+	if tokenSource == nil {
+		return option.WithCredentialsFile(settings.Credentials)
+	}
+
+	return option.WithTokenSource(tokenSource)
+}
+
+func loggedIn() bool {
+	if tokenSource == nil {
+		return true
+	}
+
+	_, err := os.Stat(settings.TokenFile)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// Logs in to Google in the browser and caches the token for this profile, replacing any earlier login
+func login() error {
+	if tokenSource == nil {
+		return errors.New(LoginNotNeededErrMsg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+
+	token, err := courseapi.LoopbackLogin(ctx, *tokenSource.Config, openBrowser)
+	if err != nil {
+		return err
+	}
+
+	err = tokenSource.Cache.Save(token)
+	if err != nil {
+		return err
+	}
+	tokenSource.Reset()
+
+	log.Printf("Logged in, token cached in %s", settings.TokenFile)
+	return nil
+}
+
+// Removes this profile's cached token and revokes it with Google. The token is removed even if
+// revoking fails, e.g. when offline, in which case the error is only logged.
+func logout() error {
+	if tokenSource == nil {
+		return errors.New(LoginNotNeededErrMsg)
+	}
+
+	token, err := tokenSource.Cache.Load()
+	if err != nil {
+		return err
+	}
+
+	err = tokenSource.Cache.Delete()
+	if err != nil {
+		return err
+	}
+	tokenSource.Reset()
+
+	ctx, cancel := context.WithTimeout(context.Background(), revokeTimeout)
+	defer cancel()
+
+	err = courseapi.RevokeToken(ctx, courseapi.GoogleRevokeURL, token)
+	if err != nil {
+		log.Printf("Unable to revoke token after logging out: %v", err)
+	}
+
+	return nil
+}
+
+// Shows the consent page in the user's browser, printing its URL in case no browser can be opened
+func openBrowser(url string) error {
+	fmt.Printf("Opening your browser to log in to Google. If it doesn't open, visit:\n\n%s\n\n", url)
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	err := cmd.Start()
+	if err != nil {
+		log.Printf("Unable to open browser: %v", err)
+		return nil
+	}
+	go cmd.Wait()
+
+	return nil
+}
+
+func runLogin() {
+	err := login()
+	if err != nil {
+		log.Printf(UnsuccessfulLoginMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully log in: %v\n", err)
+		return
+	}
+
+	fmt.Println("Logged in!")
+}
+
+func runLogout() {
+	err := logout()
+	if err != nil {
+		log.Printf(UnsuccessfulLogoutMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully log out: %v\n", err)
+		return
+	}
+
+	fmt.Println("Logged out. Run `login` to log in again.")
+}
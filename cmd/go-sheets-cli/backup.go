@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	RestoreCorrectUsageMsg = "Usage: restore <backup_name>|<file_path>"
+	BackupNotFoundErrMsg   = "no backup found with that name"
+	NoBackupsMsg           = "No backups available."
+	RestoreUpToDateMsg     = "Current courses already match this backup, nothing to restore."
+	RestoreCancelledMsg    = "Restore cancelled"
+
+	UnsuccessfulBackupMsg  = "Unable to successfully back up courses for reason"
+	UnsuccessfulRestoreMsg = "Unable to successfully restore backup for reason"
+
+	backupSheetName  = "Backups"
+	backupDirEnvKey  = "BACKUP_DIR"
+	defaultBackupDir = "backups"
+
+	// Sheets refuses cells longer than this, so larger backups are only kept locally
+	maxCellLength = 50000
+)
+
+var backupHeader = []interface{}{"name", "created_at", "courses"}
+
+func backupDir() string {
+	if dir, exists := os.LookupEnv(backupDirEnvKey); exists && dir != "" {
+		return dir
+	}
+
+	return configFile(defaultBackupDir)
+}
+
+// Writes a snapshot of every course in the current term to a local file and to the hidden backups tab
+func backup() {
+	b := courseapi.NewBackup(courses.Snapshot(), time.Now())
+	b.Term = sheetName
+
+	var buf bytes.Buffer
+	err := b.Write(&buf)
+	if err != nil {
+		log.Printf(UnsuccessfulBackupMsg+": %v", err)
+
+		fmt.Println("Unable to successfully create backup")
+		return
+	}
+
+	saved := 0
+
+	path := filepath.Join(backupDir(), b.Name+".json")
+	err = writeBackupFile(path, buf.Bytes())
+	if err != nil {
+		log.Printf(UnsuccessfulBackupMsg+": %v", err)
+
+		fmt.Printf("Unable to save backup to `%s`\n", path)
+	} else {
+		fmt.Printf("Backup saved to `%s`\n", path)
+		saved++
+	}
+
+	err = appendBackupRow(srv, b, buf.String())
+	if err != nil {
+		log.Printf(UnsuccessfulBackupMsg+": %v", err)
+
+		fmt.Printf("Unable to save backup to the `%s` tab\n", backupSheetName)
+	} else {
+		fmt.Printf("Backup saved to the `%s` tab\n", backupSheetName)
+		saved++
+	}
+
+	if saved > 0 {
+		fmt.Printf("Backup `%s` successfully created! Use `restore %s` to return to it.\n", b.Name, b.Name)
+	}
+}
+
+func writeBackupFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+func appendBackupRow(srv *sheets.Service, b courseapi.Backup, data string) error {
+	if len(data) > maxCellLength {
+		return fmt.Errorf("backup is %d characters, over the %d sheets allows in a cell", len(data), maxCellLength)
+	}
+
+	err := ensureSheetTab(srv, backupSheetName, backupHeader, true)
+	if err != nil {
+		return err
+	}
+
+	writeRange := courseapi.TabRange(backupSheetName, "A:C")
+	_, err = srv.Spreadsheets.Values.Append(spreadsheetId, writeRange, &sheets.ValueRange{
+		Values: [][]interface{}{{b.Name, b.CreatedAt.Format(time.RFC3339), data}},
+	}).ValueInputOption("RAW").InsertDataOption("INSERT_ROWS").Do()
+	if err != nil {
+		return fmt.Errorf("failed to append backup: %v", err)
+	}
+
+	return nil
+}
+
+// Reads the rows of the backups tab, keyed by backup name
+func loadBackupRows(srv *sheets.Service) (map[string]string, error) {
+	err := ensureSheetTab(srv, backupSheetName, backupHeader, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetId, courseapi.TabRange(backupSheetName, "A2:C")).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read backups: %v", err)
+	}
+
+	rows := make(map[string]string)
+	for _, row := range resp.Values {
+		if len(row) < 3 {
+			continue // Skip incomplete rows
+		}
+
+		rows[fmt.Sprint(row[0])] = fmt.Sprint(row[2])
+	}
+
+	return rows, nil
+}
+
+// Finds a backup by file path, by name in the local backup directory, or by name in the backups tab
+func findBackup(nameOrPath string) (courseapi.Backup, error) {
+	for _, path := range []string{nameOrPath, filepath.Join(backupDir(), nameOrPath+".json")} {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+
+		return courseapi.ReadBackup(f)
+	}
+
+	rows, err := loadBackupRows(srv)
+	if err != nil {
+		return courseapi.Backup{}, err
+	}
+
+	data, exists := rows[nameOrPath]
+	if !exists {
+		return courseapi.Backup{}, errors.New(BackupNotFoundErrMsg)
+	}
+
+	return courseapi.ReadBackup(strings.NewReader(data))
+}
+
+func listBackups() {
+	locations := make(map[string][]string)
+
+	files, _ := filepath.Glob(filepath.Join(backupDir(), "backup-*.json"))
+	for _, path := range files {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		locations[name] = append(locations[name], "local")
+	}
+
+	rows, err := loadBackupRows(srv)
+	if err != nil {
+		log.Printf("Unable to list backups in sheets: %v", err)
+	}
+	for name := range rows {
+		locations[name] = append(locations[name], "sheet")
+	}
+
+	if len(locations) == 0 {
+		fmt.Println(NoBackupsMsg)
+		return
+	}
+
+	names := make([]string, 0, len(locations))
+	for name := range locations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s (%s)\n", name, strings.Join(locations[name], ", "))
+	}
+}
+
+// Previews how a backup differs from the current courses, then rewrites the sheet to match it once confirmed
+func restore(nameOrPath string, reader *bufio.Reader) {
+	b, err := findBackup(nameOrPath)
+	if err != nil {
+		log.Printf(UnsuccessfulRestoreMsg+": %v", err)
+
+		fmt.Printf("Unable to load backup `%s`: %v\n", nameOrPath, err)
+		return
+	}
+
+	if !inCurrentTerm(b.Term) {
+		term := b.Term
+		if term == "" {
+			term = defaultTermName
+		}
+
+		fmt.Printf("Backup `%s` was taken in term `%s`, switch to it with `use-term` before restoring\n", b.Name, term)
+		return
+	}
+
+	current := courses.Snapshot()
+	changes := b.RestoreChanges(current)
+	if len(changes) == 0 {
+		fmt.Println(RestoreUpToDateMsg)
+		return
+	}
+
+	fmt.Printf("Restoring `%s` (taken %s) will make these changes:\n", b.Name, b.CreatedAt.Local().Format(courseapi.HistoryTimeFormat))
+	fmt.Println(courseapi.DiffCourseMaps(current, b.Courses).String())
+	fmt.Print("Continue? (y/N) > ")
+
+	input, _ := reader.ReadString('\n')
+	if strings.ToLower(strings.TrimSpace(input)) != "y" {
+		fmt.Println(RestoreCancelledMsg)
+		return
+	}
+
+	err = restoreBackup(b)
+	if err != nil {
+		log.Printf(UnsuccessfulRestoreMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully restore `%s`\n", b.Name)
+		return
+	}
+
+	command := "restore " + b.Name
+	for _, change := range changes {
+		auditChange(command, change.Before, change.After)
+	}
+	recordMutation(command, changes...)
+
+	fmt.Printf("Backup `%s` successfully restored!\n", b.Name)
+}
+
+// Replaces every course row with the backup's contents. Holds the refresh lock throughout so
+// no other write can land in between.
+func restoreBackup(b courseapi.Backup) error {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	err := courseapi.RewriteCourseRows(context.Background(), srv, spreadsheetId, sheetName, b.Courses)
+	if err != nil {
+		return err
+	}
+
+	courses.Reset(b.Courses)
+	return nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	courseapi "go-sheets/courseapi"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	ConfigCorrectUsageMsg = "Usage: config"
+
+	UnsuccessfulConfigLoadMsg = "Unable to successfully load configuration"
+
+	configFileEnvKey    = "GOSHEETS_CONFIG"
+	profileEnvKey       = "GOSHEETS_PROFILE"
+	authEnvKey          = "GOSHEETS_AUTH"
+	credentialsEnvKey   = "GOSHEETS_CREDENTIALS"
+	tokenFileEnvKey     = "GOSHEETS_TOKEN_FILE"
+	spreadsheetIdEnvKey = "SPREADSHEET_ID"
+	timeZoneEnvKey      = "GOSHEETS_TIME_ZONE"
+	dateFormatEnvKey    = "GOSHEETS_DATE_FORMAT"
+	logFileEnvKey       = "GOSHEETS_LOG_FILE"
+	shareWithEnvKey     = "GOSHEETS_SHARE_WITH"
+
+	defaultCredentialsFile = "service-account.json"
+	defaultTokenFile       = "token.json"
+	defaultEnvFile         = ".env"
+	defaultLogFile         = "gosheets-cli.log"
+	defaultTimeZone        = "Local"
+	defaultDatePattern     = "MM/DD/YY"
+)
+
+var (
+	// The effective settings, after the config file, environment and flags are layered
+	settings    courseapi.Settings
+	profileName string
+	configPath  string
+
+	configFlag        = flag.String("config", "", "path of the config file (default $XDG_CONFIG_HOME/go-sheets/config.json)")
+	profileFlag       = flag.String("profile", "", "profile from the config file to use")
+	authFlag          = flag.String("auth", "", "how to authenticate with Google: service-account or oauth")
+	credentialsFlag   = flag.String("credentials", "", "path of the service account credentials, or the OAuth client with --auth oauth")
+	tokenFileFlag     = flag.String("token-file", "", "path of the cached OAuth token")
+	spreadsheetIdFlag = flag.String("spreadsheet-id", "", "ID of the spreadsheet to use")
+	tabFlag           = flag.String("tab", "", "sheet tab (term) to use")
+	timeZoneFlag      = flag.String("time-zone", "", "IANA time zone, e.g. America/New_York")
+	dateFormatFlag    = flag.String("date-format", "", "date format, e.g. MM/DD/YY or YYYY-MM-DD")
+	logFileFlag       = flag.String("log-file", "", "path of the log file")
+	envFileFlag       = flag.String("env-file", "", "path of the .env file holding other settings")
+	shareFlag         = flag.String("share", "", "addresses to share a newly created spreadsheet with, e.g. alice@example.com,bob@example.com:writer")
+)
+
+// Works out the settings to run with. Each layer overrides the one before it: built-in defaults,
+// the config file's shared settings, the chosen profile, environment variables (including those
+// in the .env file), then command line flags. The time zone and date format are applied globally.
+func loadSettings() error {
+	flag.Parse()
+
+	configPath = firstSet(*configFlag, os.Getenv(configFileEnvKey))
+	if configPath == "" {
+		path, err := courseapi.DefaultConfigPath()
+		if err != nil {
+			return err
+		}
+		configPath = path
+	}
+
+	config, err := courseapi.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	resolved, name, err := config.Resolve(firstSet(*profileFlag, os.Getenv(profileEnvKey)))
+	if err != nil {
+		return err
+	}
+	profileName = name
+	resolved = resolved.RelativeTo(filepath.Dir(configPath))
+
+	// Each profile keeps its own .env next to the config file, so their settings don't mix
+	resolved.EnvFile = firstSet(*envFileFlag, resolved.EnvFile)
+	if resolved.EnvFile == "" && profileName != "" {
+		resolved.EnvFile = configFile(profileName + ".env")
+	}
+	if resolved.EnvFile == "" {
+		resolved.EnvFile = configFile(defaultEnvFile)
+	}
+
+	err = godotenv.Load(resolved.EnvFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to read `%s`: %v", resolved.EnvFile, err)
+	}
+
+	fromEnv := courseapi.Settings{
+		Auth:          os.Getenv(authEnvKey),
+		Credentials:   os.Getenv(credentialsEnvKey),
+		TokenFile:     os.Getenv(tokenFileEnvKey),
+		SpreadsheetID: os.Getenv(spreadsheetIdEnvKey),
+		Tab:           os.Getenv(currentTermEnvKey),
+		TimeZone:      os.Getenv(timeZoneEnvKey),
+		DateFormat:    os.Getenv(dateFormatEnvKey),
+		LogFile:       os.Getenv(logFileEnvKey),
+		ShareWith:     os.Getenv(shareWithEnvKey),
+		SMTPHost:      os.Getenv(smtpHostEnvKey),
+		SMTPPort:      os.Getenv(smtpPortEnvKey),
+		SMTPUsername:  os.Getenv(smtpUsernameEnvKey),
+		SMTPPassword:  os.Getenv(smtpPasswordEnvKey),
+		DigestFrom:    os.Getenv(digestFromEnvKey),
+		DigestTo:      os.Getenv(digestToEnvKey),
+	}
+
+	defaults := courseapi.Settings{
+		Auth:       courseapi.ServiceAccountAuth,
+		Tab:        defaultTermName,
+		TimeZone:   defaultTimeZone,
+		DateFormat: defaultDatePattern,
+		LogFile:    configFile(defaultLogFile),
+	}
+	fromFlags := courseapi.Settings{
+		Auth:          *authFlag,
+		Credentials:   *credentialsFlag,
+		TokenFile:     *tokenFileFlag,
+		SpreadsheetID: *spreadsheetIdFlag,
+		Tab:           *tabFlag,
+		TimeZone:      *timeZoneFlag,
+		DateFormat:    *dateFormatFlag,
+		LogFile:       *logFileFlag,
+		ShareWith:     *shareFlag,
+	}
+
+	settings = defaults.Merge(resolved).Merge(fromEnv).Merge(fromFlags)
+
+	// The credentials file is a service account key or an OAuth client depending on auth, and each
+	// profile caches its own login next to the config file
+	if settings.Credentials == "" && settings.Auth == courseapi.OAuthAuth {
+		settings.Credentials = configFile(defaultOAuthClientFile)
+	} else if settings.Credentials == "" {
+		settings.Credentials = configFile(defaultCredentialsFile)
+	}
+	if settings.TokenFile == "" && profileName != "" {
+		settings.TokenFile = configFile(profileName + ".token.json")
+	}
+	if settings.TokenFile == "" {
+		settings.TokenFile = configFile(defaultTokenFile)
+	}
+
+	err = settings.Validate()
+	if err != nil {
+		return err
+	}
+
+	loc, _ := courseapi.LoadTimeZone(settings.TimeZone)
+	time.Local = loc
+	courseapi.DateFormat, _ = courseapi.ParseDatePattern(settings.DateFormat)
+
+	return nil
+}
+
+// Where go-sheets keeps a file unless told otherwise: next to the config file, so it works the
+// same from any directory
+func configFile(name string) string {
+	return filepath.Join(filepath.Dir(configPath), name)
+}
+
+func firstSet(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Shows where settings come from and what they resolved to
+func showConfig() {
+	status := ""
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		status = " (not created)"
+	}
+	fmt.Printf("Config file: %s%s\n", configPath, status)
+
+	config, err := courseapi.LoadConfig(configPath)
+	if err == nil && len(config.Profiles) > 0 {
+		names := config.ProfileNames()
+		for i, name := range names {
+			if name == profileName {
+				names[i] = name + " (active)"
+			}
+		}
+		fmt.Printf("Profiles: %s\n", strings.Join(names, ", "))
+	}
+
+	if profileName == "" {
+		fmt.Println("Profile: none")
+	} else {
+		fmt.Printf("Profile: %s\n", profileName)
+	}
+
+	fmt.Printf("Auth: %s\n", settings.Auth)
+	fmt.Printf("Credentials: %s\n", settings.Credentials)
+	if settings.Auth == courseapi.OAuthAuth {
+		status := "logged in"
+		if !loggedIn() {
+			status = "not logged in"
+		}
+		fmt.Printf("Token file: %s (%s)\n", settings.TokenFile, status)
+	}
+	fmt.Printf("Spreadsheet ID: %s\n", spreadsheetId)
+	fmt.Printf("Tab: %s\n", sheetName)
+	fmt.Printf("Time zone: %s\n", settings.TimeZone)
+	fmt.Printf("Date format: %s\n", settings.DateFormat)
+	fmt.Printf("Log file: %s\n", settings.LogFile)
+	fmt.Printf(".env file: %s\n", settings.EnvFile)
+	if settings.ShareWith != "" {
+		fmt.Printf("Share new spreadsheets with: %s\n", settings.ShareWith)
+	}
+	if settings.SMTPHost != "" {
+		smtp, _ := settings.SMTP()
+		fmt.Printf("Digest: from %s to %s via %s:%d\n", smtp.From, strings.Join(smtp.To, ", "), smtp.Host, smtp.Port)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	ImportCSVCorrectUsageMsg = "Usage: import-csv <file_path>"
+	ExportCSVCorrectUsageMsg = "Usage: export-csv <file_path> [<course_name>]"
+	ImportCSVNoRowsMsg       = "No assignments found in CSV, nothing imported"
+
+	UnsuccessfulCSVImportMsg = "Unable to successfully import CSV for reason"
+	UnsuccessfulCSVExportMsg = "Unable to successfully export CSV for reason"
+)
+
+// Imports every assignment in the CSV at path as a single batch: if any line fails
+// validation or the sheet write fails, no course is changed.
+func importCSV(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf(UnsuccessfulCSVImportMsg+": %v", err)
+
+		fmt.Printf("Unable to open `%s`\n", path)
+		return
+	}
+	defer f.Close()
+
+	records, err := courseapi.ParseAssignmentsCSV(f)
+	if err != nil {
+		reportImportFailure(path, err)
+		return
+	}
+
+	if len(records) == 0 {
+		fmt.Println(ImportCSVNoRowsMsg)
+		return
+	}
+
+	snapshot := courses.Snapshot()
+	updated, err := snapshot.ApplyCSVRecords(records)
+	if err != nil {
+		reportImportFailure(path, err)
+		return
+	}
+
+	err = commitCourseUpdates("import-csv "+path, snapshot, updated)
+	if err != nil {
+		reportImportFailure(path, err)
+		return
+	}
+
+	var changes []courseapi.CourseSnapshot
+	for name, course := range updated {
+		changes = append(changes, courseUpdated(*snapshot[name], *course))
+	}
+	recordMutation("import-csv "+path, changes...)
+
+	fmt.Printf("Imported %d assignment(s) into %d course(s) from `%s`\n", len(records), len(updated), path)
+}
+
+func reportImportFailure(path string, err error) {
+	log.Printf(UnsuccessfulCSVImportMsg+": %v", err)
+
+	var importErr *courseapi.CSVImportError
+	if errors.As(err, &importErr) {
+		fmt.Printf("Nothing imported, %d line(s) failed validation:\n%v\n", len(importErr.Errors), importErr)
+	} else {
+		fmt.Printf("Nothing imported from `%s`: %v\n", path, err)
+	}
+}
+
+// Writes the export beside path first and only moves it into place once it's complete, so a
+// failed export, e.g. of a course that doesn't exist, leaves any existing file untouched
+func exportCSV(path string, courseNames ...string) {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		log.Printf(UnsuccessfulCSVExportMsg+": %v", err)
+
+		fmt.Printf("Unable to create `%s`\n", path)
+		return
+	}
+
+	err = courses.Snapshot().WriteAssignmentsCSV(f, courseNames...)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		log.Printf(UnsuccessfulCSVExportMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully export to `%s`: %v\n", path, err)
+		return
+	}
+
+	fmt.Printf("Assignments successfully exported to `%s`!\n", path)
+}
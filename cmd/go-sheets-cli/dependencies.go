@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	AddPrereqCorrectUsageMsg    = "Usage: add-prereq <course_name> <assignment_number> <prereq_course_name> <prereq_assignment_number>"
+	RemovePrereqCorrectUsageMsg = "Usage: remove-prereq <course_name> <assignment_number> <prereq_number>"
+	DepsCorrectUsageMsg         = "Usage: deps [<course_name>]"
+
+	UnsuccessfulPrereqUpdateMsg = "Unable to successfully update prerequisites for reason"
+)
+
+// Makes an assignment depend on another, possibly in a different course
+func addPrereq(command string, courseName string, numberArg string, prereqCourse string, prereqNumberArg string) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	prereqIndex, err := parseAssignmentNumber(prereqNumberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	editPrereqs(command, courseName, index, numberArg, func(c *CourseItem) error {
+		// Cycles are checked against every course, with this one swapped for the copy being edited
+		cm := courses.Snapshot()
+		cm[courseName] = c
+		return cm.AddPrerequisite(courseName, index, prereqCourse, prereqIndex)
+	})
+}
+
+func removePrereq(command string, courseName string, numberArg string, prereqArg string) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	prereq, err := parseAssignmentNumber(prereqArg)
+	if err != nil {
+		fmt.Println(RemovePrereqCorrectUsageMsg)
+		return
+	}
+
+	editPrereqs(command, courseName, index, numberArg, func(c *CourseItem) error {
+		return c.RemovePrerequisite(index, prereq)
+	})
+}
+
+// Applies edit to a course, then shows the edited assignment and any prerequisite warnings for the course
+func editPrereqs(command string, courseName string, index int, numberArg string, edit func(*CourseItem) error) {
+	updated, err := editCourse(command, courseName, edit)
+	if err != nil {
+		log.Printf(UnsuccessfulPrereqUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update prerequisites of assignment number `%s`: %v\n", numberArg, err)
+		return
+	}
+
+	fmt.Println(updated.Assignments[index].String())
+	showDependencyWarnings(courseName)
+}
+
+// Lists prerequisite problems, for assignments in the given course or in every course
+func showDependencyWarnings(courseName string) {
+	if courseName != "" {
+		if _, exists := courses.Get(courseName); !exists {
+			fmt.Printf("Course `%s` doesn't exist\n", courseName)
+			return
+		}
+	}
+
+	var warnings []courseapi.DependencyWarning
+	for _, w := range courses.Snapshot().DependencyWarnings(time.Now(), courseapi.DefaultPrerequisiteWarningDays) {
+		if courseName == "" || w.Dependent.Course == courseName {
+			warnings = append(warnings, w)
+		}
+	}
+
+	if len(warnings) == 0 {
+		fmt.Println("No prerequisite warnings.")
+		return
+	}
+
+	for _, w := range warnings {
+		fmt.Println("! " + w.String())
+	}
+}
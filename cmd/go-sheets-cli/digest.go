@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	DigestCorrectUsageMsg = "Usage: digest [--dry-run]"
+
+	UnsuccessfulDigestMsg = "Unable to successfully send digest for reason"
+
+	smtpHostEnvKey     = "SMTP_HOST"
+	smtpPortEnvKey     = "SMTP_PORT"
+	smtpUsernameEnvKey = "SMTP_USERNAME"
+	smtpPasswordEnvKey = "SMTP_PASSWORD"
+	digestFromEnvKey   = "DIGEST_FROM"
+	digestToEnvKey     = "DIGEST_TO"
+)
+
+// Emails a digest of the next week's deadlines across every course, or prints it with --dry-run
+func digest(args []string) {
+	dryRun := false
+	for _, arg := range args {
+		if arg != "--dry-run" {
+			fmt.Println(DigestCorrectUsageMsg)
+			return
+		}
+		dryRun = true
+	}
+
+	now := time.Now()
+	// Settings were validated at startup, so the port parses
+	config, _ := settings.SMTP()
+	d := courseapi.NewDigest(courses.Snapshot(), now, courseapi.DefaultDigestDays)
+
+	if dryRun {
+		text, err := d.Text()
+		if err != nil {
+			log.Printf(UnsuccessfulDigestMsg+": %v", err)
+
+			fmt.Println("Unable to successfully render digest")
+			return
+		}
+
+		fmt.Printf("From: %s\nTo: %s\nSubject: %s\n\n%s", config.From, strings.Join(config.To, ", "), d.Subject(), text)
+		return
+	}
+
+	msg, err := d.Message(config.From, config.To, now)
+	if err != nil {
+		log.Printf(UnsuccessfulDigestMsg+": %v", err)
+
+		fmt.Println("Unable to successfully render digest")
+		return
+	}
+
+	err = courseapi.SendMail(config, msg)
+	if err != nil {
+		log.Printf(UnsuccessfulDigestMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully send digest: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Digest of %d deadline(s) sent to %s\n", d.Total, strings.Join(config.To, ", "))
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const FindCorrectUsageMsg = "Usage: find <filter> (e.g. due<7d course:CS* status:open tag:exam text:\"midterm\")"
+
+// Lists the assignments of every course in the current term that match the filter expression
+func find(filter string) {
+	if filter == "" {
+		fmt.Println(FindCorrectUsageMsg)
+		return
+	}
+
+	f, err := courseapi.ParseFilter(filter)
+	if err != nil {
+		fmt.Printf("Invalid filter: %v\n", err)
+		return
+	}
+
+	fmt.Println(f.Find(courses.Snapshot(), time.Now()).String(true))
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	SetCategoryCorrectUsageMsg    = "Usage: set-category <course_name> <category_name> <weight>"
+	RemoveCategoryCorrectUsageMsg = "Usage: remove-category <course_name> <category_name>"
+	SetPointsCorrectUsageMsg      = "Usage: set-points <course_name> <assignment_number> <points_possible> [<category_name>]"
+	RecordScoreCorrectUsageMsg    = "Usage: record-score <course_name> <assignment_number> <points_earned>|-"
+	GradeCorrectUsageMsg          = "Usage: grade <course_name>"
+	SetCutoffsCorrectUsageMsg     = "Usage: set-cutoffs <course_name> <letter>=<min_percent> ...|default"
+	WhatIfCorrectUsageMsg         = "Usage: what-if <course_name> [<assignment_number>=<score> ...] [assume=<percent>]"
+	NeedCorrectUsageMsg           = "Usage: need <course_name> <assignment_number> <letter> [assume=<percent>]"
+	InvalidNumberMsg              = "Weights, points and scores must be numbers"
+
+	UnsuccessfulGradingUpdateMsg = "Unable to successfully update grading for reason"
+)
+
+// Parses a number argument, rejecting the NaN and Inf that strconv also accepts
+func parseNumberArg(arg string) (float64, bool) {
+	value, err := strconv.ParseFloat(arg, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		fmt.Println(InvalidNumberMsg)
+		return 0, false
+	}
+
+	return value, true
+}
+
+// Runs a grading edit through editCourse, reporting the outcome
+func editGrading(command string, courseName string, successMsg string, edit func(*CourseItem) error) {
+	_, err := editCourse(command, courseName, edit)
+	if err != nil {
+		log.Printf(UnsuccessfulGradingUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update course `%s`: %v\n", courseName, err)
+		return
+	}
+
+	fmt.Println(successMsg)
+}
+
+func setCategory(command string, courseName string, categoryName string, weightArg string) {
+	weight, ok := parseNumberArg(weightArg)
+	if !ok {
+		return
+	}
+
+	editGrading(command, courseName, fmt.Sprintf("Category `%s` now weighted %g in `%s`", categoryName, weight, courseName),
+		func(c *CourseItem) error {
+			return c.SetCategory(categoryName, weight)
+		})
+}
+
+func removeCategory(command string, courseName string, categoryName string) {
+	editGrading(command, courseName, fmt.Sprintf("Category `%s` removed from `%s`", categoryName, courseName),
+		func(c *CourseItem) error {
+			return c.RemoveCategory(categoryName)
+		})
+}
+
+func setPoints(command string, courseName string, numberArg string, possibleArg string, category ...string) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	possible, ok := parseNumberArg(possibleArg)
+	if !ok {
+		return
+	}
+
+	editGrading(command, courseName, fmt.Sprintf("Assignment number `%s` is now worth %g points", numberArg, possible),
+		func(c *CourseItem) error {
+			return c.SetAssignmentPoints(index, possible, category...)
+		})
+}
+
+// Records a score, or clears it when scoreArg is `-`
+func recordScore(command string, courseName string, numberArg string, scoreArg string) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if scoreArg == "-" {
+		editGrading(command, courseName, fmt.Sprintf("Score cleared for assignment number `%s`", numberArg),
+			func(c *CourseItem) error {
+				return c.ClearScore(index)
+			})
+		return
+	}
+
+	earned, ok := parseNumberArg(scoreArg)
+	if !ok {
+		return
+	}
+
+	editGrading(command, courseName, fmt.Sprintf("Score of %g recorded for assignment number `%s`", earned, numberArg),
+		func(c *CourseItem) error {
+			return c.RecordScore(index, earned)
+		})
+}
+
+func showGrade(courseName string) {
+	courseItem, exists := courses.Get(courseName)
+	if !exists {
+		fmt.Println(CourseDoesntExistErrMsg)
+		return
+	}
+
+	grade, err := courseItem.Grade()
+	if err != nil {
+		fmt.Printf("No grade for `%s` yet: %v\n", courseName, err)
+		return
+	}
+
+	fmt.Printf("%s\nLetter grade: %s\n", grade.String(), courseItem.LetterFor(grade.Percent))
+}
+
+// Splits `assume=<percent>` out of the remaining arguments, if present
+func parseAssumeArg(args []string) ([]string, *float64, bool) {
+	var rest []string
+	var assume *float64
+
+	for _, arg := range args {
+		value, found := strings.CutPrefix(arg, "assume=")
+		if !found {
+			rest = append(rest, arg)
+			continue
+		}
+
+		percent, ok := parseNumberArg(strings.TrimSuffix(value, "%"))
+		if !ok {
+			return nil, nil, false
+		}
+		assume = &percent
+	}
+
+	return rest, assume, true
+}
+
+func setCutoffs(command string, courseName string, args []string) {
+	var cutoffs []courseapi.LetterCutoff
+	if len(args) != 1 || args[0] != "default" {
+		var err error
+		cutoffs, err = courseapi.ParseGradeCutoffs(args)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	editGrading(command, courseName, fmt.Sprintf("Grade cutoffs updated for `%s`", courseName),
+		func(c *CourseItem) error {
+			c.GradeCutoffs = cutoffs
+			return nil
+		})
+}
+
+// Projects the course grade from hypothetical `<assignment_number>=<score>` arguments
+func whatIf(courseName string, args []string) {
+	courseItem, exists := courses.Get(courseName)
+	if !exists {
+		fmt.Println(CourseDoesntExistErrMsg)
+		return
+	}
+
+	args, assume, ok := parseAssumeArg(args)
+	if !ok {
+		return
+	}
+
+	scores := make(map[int]float64)
+	for _, arg := range args {
+		numberArg, scoreArg, found := strings.Cut(arg, "=")
+		if !found {
+			fmt.Println(WhatIfCorrectUsageMsg)
+			return
+		}
+
+		index, err := parseAssignmentNumber(numberArg)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		score, ok := parseNumberArg(scoreArg)
+		if !ok {
+			return
+		}
+		scores[index] = score
+	}
+
+	grade, err := courseItem.WhatIf(scores, assume)
+	if err != nil {
+		fmt.Printf("Unable to project a grade for `%s`: %v\n", courseName, err)
+		return
+	}
+
+	fmt.Printf("Projected grade: %.2f%% (%s)\n", grade.Percent, courseItem.LetterFor(grade.Percent))
+}
+
+func need(courseName string, numberArg string, letter string, args []string) {
+	courseItem, exists := courses.Get(courseName)
+	if !exists {
+		fmt.Println(CourseDoesntExistErrMsg)
+		return
+	}
+
+	args, assume, ok := parseAssumeArg(args)
+	if !ok {
+		return
+	}
+
+	if len(args) > 0 {
+		fmt.Println(NeedCorrectUsageMsg)
+		return
+	}
+
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	required, err := courseItem.RequiredScore(index, letter, assume)
+	if err != nil {
+		fmt.Printf("Unable to solve for a score: %v\n", err)
+		return
+	}
+
+	fmt.Println(required.String())
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	HooksCorrectUsageMsg = "Usage: hooks"
+
+	UnsuccessfulHookMsg = "Hook failed"
+
+	hooksDirEnvKey    = "HOOKS_DIR"
+	hookTimeoutEnvKey = "HOOK_TIMEOUT"
+	defaultHooksDir   = "hooks"
+
+	// Events waiting for their hooks beyond this many are dropped, so a slow hook can't stall commands
+	hookQueueSize = 100
+)
+
+var (
+	// Guards hookQueue and hooksClosed, so background goroutines can't send on the queue as it's closed
+	hookMu      sync.Mutex
+	hookQueue   chan courseapi.Event
+	hooksClosed bool
+	hookWorker  sync.WaitGroup
+)
+
+var hookEventTypes = []string{
+	courseapi.EventAssignmentCreated,
+	courseapi.EventAssignmentUpdated,
+	courseapi.EventAssignmentRemoved,
+	courseapi.EventAssignmentDueSoon,
+	courseapi.EventAssignmentOverdue,
+}
+
+func hookRunner() courseapi.HookRunner {
+	runner := courseapi.HookRunner{Dir: configFile(defaultHooksDir), Timeout: courseapi.DefaultHookTimeout}
+	if dir, exists := os.LookupEnv(hooksDirEnvKey); exists && dir != "" {
+		runner.Dir = dir
+	}
+
+	if setting, exists := os.LookupEnv(hookTimeoutEnvKey); exists && setting != "" {
+		timeout, err := time.ParseDuration(setting)
+		if err == nil && timeout > 0 {
+			runner.Timeout = timeout
+		} else {
+			log.Printf("Ignoring invalid %s `%s`", hookTimeoutEnvKey, setting)
+		}
+	}
+
+	return runner
+}
+
+// Queues events for their hooks, which run one at a time in the background in the order events happened
+func emitEvents(events ...courseapi.Event) {
+	actor := currentActor()
+
+	hookMu.Lock()
+	defer hookMu.Unlock()
+
+	if hooksClosed {
+		for _, event := range events {
+			log.Printf("Dropping %s event for `%s`, hooks have stopped for exit", event.Type, event.Course)
+		}
+		return
+	}
+
+	if hookQueue == nil {
+		queue := make(chan courseapi.Event, hookQueueSize)
+		hookQueue = queue
+		hookWorker.Add(1)
+
+		go func() {
+			defer hookWorker.Done()
+
+			for event := range queue {
+				for _, err := range hookRunner().Run(context.Background(), event) {
+					log.Printf(UnsuccessfulHookMsg+": %v", err)
+				}
+			}
+		}()
+	}
+
+	for _, event := range events {
+		event.Actor = actor
+
+		select {
+		case hookQueue <- event:
+		default:
+			log.Printf("Dropping %s event for `%s`, too many events are waiting for hooks", event.Type, event.Course)
+		}
+	}
+}
+
+// Emits an event for every assignment a persisted change created, updated or removed
+func emitChangeEvents(command string, before *CourseItem, after *CourseItem) {
+	events := courseapi.AssignmentChangeEvents(before, after, time.Now())
+	for i := range events {
+		events[i].Command = command
+	}
+
+	emitEvents(events...)
+}
+
+// Lets queued hooks finish before exiting; each is bounded by the hook timeout. Events emitted
+// afterwards, e.g. by a reminder check already under way, are dropped.
+func waitForHooks() {
+	hookMu.Lock()
+	hooksClosed = true
+	if hookQueue != nil {
+		close(hookQueue)
+	}
+	hookMu.Unlock()
+
+	hookWorker.Wait()
+}
+
+// Lists which hooks will run for each event
+func showHooks() {
+	runner := hookRunner()
+	fmt.Printf("Hooks directory: %s (timeout %s)\n", runner.Dir, runner.Timeout)
+
+	for _, eventType := range hookEventTypes {
+		hooks, err := runner.Hooks(eventType)
+		if err != nil {
+			log.Printf("Unable to read hooks directory `%s`: %v", runner.Dir, err)
+
+			fmt.Printf("Unable to successfully read hooks directory `%s`\n", runner.Dir)
+			return
+		}
+
+		if len(hooks) == 0 {
+			fmt.Printf("  %s: none\n", eventType)
+		} else {
+			fmt.Printf("  %s: %s\n", eventType, strings.Join(hooks, ", "))
+		}
+	}
+}
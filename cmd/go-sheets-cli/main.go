@@ -5,33 +5,39 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	courseapi "go-sheets/courseapi"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
-	"github.com/joho/godotenv"
-	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
 const (
 	WelcomeMsg                            = "Welcome to the Go-Sheets CLI! Type 'info' for a list of accepted commands, or 'exit' to quit."
-	AssignmentInfoMsg                     = "Please input additional <due_date> (MM/DD/YY) and optional [<assignment_info>], space-delimited"
-	ListAssignmentsCorrectUsageMsg        = "Usage: list-assignments <course_name>"
+	AssignmentInfoMsg                     = "Please input additional <due_date> (%s) and optional [<assignment_info>], space-delimited"
+	ListAssignmentsCorrectUsageMsg        = "Usage: list-assignments <course_name> [<filter>]"
 	CreateCourseCorrectUsageMsg           = "Usage: create-course <course_name> [<course_description>]"
 	CreateAssignmentCorrectUsageMsg       = "Usage: create-assignment <course_name> <assignment_name>"
 	RemoveCourseCorrectUsageMsg           = "Usage: remove-course <course_name>"
 	RemoveAssignmentCorrectUsageMsg       = "Usage: remove-assignment <course_name> <assignment_number>"
-	CreateAssignmentCorrectFieldsMsg      = "Fields: <due_date> (MM/DD/YY) [<assignment_info>]"
+	CreateAssignmentCorrectFieldsMsg      = "Fields: <due_date> (%s) [<assignment_info>]"
 	AssignmentCourseDoesntExistMsg        = "Course for assignment doesn't exist"
 	RemovalCourseDoesntExistMsg           = "Course to remove doesn't exist"
 	ValidRemoveIndexMsg                   = "Removal index must be a valid integer"
 	AssignmentRemovalCourseDoesntExistMsg = "Course for assignment removal doesn't exist"
 	RemoveIndexOutOfBoundsMsg             = "Removal index out of bounds (check indices using `list-assignments <coursename>`)"
 	CourseAlreadyExistsErrMsg             = "this course has already been added"
+	CourseChangedConcurrentlyErrMsg       = "course was changed by someone else, please retry"
+	CourseDoesntExistErrMsg               = "course doesn't exist"
+	InvalidAssignmentNumberErrMsg         = "assignment number must be a valid integer"
 
 	UnsuccessfulLogSetupMsg      = "Unable to successfully setup logging file"
 	UnsuccessfulSheetsSetupMsg   = "Unable to successfully connect to sheets service"
@@ -41,45 +47,142 @@ const (
 	UnsuccessfulCourseRemovalMsg      = "Unable to successfully remove course"
 	UnsuccessfulAssignmentCreationMsg = "Unable to successfully create assignment for reason"
 	UnsuccessfulAssignmentRemovalMsg  = "Unable to successfully remove assignment for reason"
+)
 
-	logFile   = "gosheets-cli.log"
-	sheetName = "Sheet1"
+// Returned when a commit loses a race with another change, so callers can tell them apart from
+// failures to reach the sheet with errors.Is, even once wrapped
+var (
+	ErrCourseAlreadyExists       = errors.New(CourseAlreadyExistsErrMsg)
+	ErrCourseChangedConcurrently = errors.New(CourseChangedConcurrentlyErrMsg)
 )
 
 type CourseMap = courseapi.CourseMap
+type CourseStore = courseapi.CourseStore
 type CourseItem = courseapi.CourseItem
 type AssignmentList = courseapi.AssignmentList
 type AssignmentItem = courseapi.AssignmentItem
 type Service = sheets.Service
 
 var (
-	courseMap     CourseMap
+	courses       *CourseStore
 	srv           *Service
 	spreadsheetId string
+
+	// The current term's tab; every course command reads and writes only this tab
+	sheetName = defaultTermName
+)
+
+var (
+	// Tabs already known to exist, so each is only checked once per run
+	ensuredTabs   = make(map[string]bool)
+	ensuredTabsMu sync.Mutex
 )
 
-func init() {
-	_, err := initLog()
+// Loads settings and connects to the configured spreadsheet. Runs first thing in main rather than
+// as init, so tests of this package don't try to connect.
+func setup() {
+	err := loadSettings()
+	if err != nil {
+		log.Fatalf(UnsuccessfulConfigLoadMsg+": %v", err)
+	}
+
+	_, err = initLog()
 	if err != nil {
 		log.Fatalf(UnsuccessfulLogSetupMsg+": %v", err)
 	}
 
-	srv, err = getSheetsService()
+	err = initAuth()
+	if err != nil {
+		log.Fatalf(UnsuccessfulSheetsSetupMsg+": %v", err)
+	}
+
+	// These set up or diagnose the connection themselves, so they must run before there is one
+	switch flag.Arg(0) {
+	case "login", "logout", "init", "doctor":
+		sheetName = settings.Tab
+		return
+	}
+
+	if settings.SpreadsheetID == "" {
+		fmt.Fprintf(os.Stderr, "No spreadsheet is configured; run `go-sheets-cli init` to create one or use an existing one\n")
+		log.Fatalf(UnsuccessfulSpreadsheetSetupMsg+": %s", NoSpreadsheetErrMsg)
+	}
+
+	// The interactive prompt logs in on first run; subcommands, often run unattended, need `login` first
+	if !loggedIn() {
+		if flag.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "Not logged in to Google; run `go-sheets-cli login` first\n")
+			log.Fatalf(UnsuccessfulSheetsSetupMsg+": %s", courseapi.NotLoggedInErrMsg)
+		}
+
+		fmt.Println("go-sheets isn't logged in to Google yet.")
+		err = login()
+		if err != nil {
+			log.Fatalf(UnsuccessfulLoginMsg+": %v", err)
+		}
+	}
+
+	err = connect()
 	if err != nil {
 		log.Fatalf(UnsuccessfulSheetsSetupMsg+": %v", err)
 	}
 
-	spreadsheetId = getOrCreateSpreadsheet(srv, "Course Tracking Sheet")
+	spreadsheetId = settings.SpreadsheetID
 	log.Printf("Using spreadsheet id: %s", spreadsheetId)
 
-	courseMap, err = loadCourseMapFromSheets(srv, spreadsheetId)
+	err = initTerm()
+	if err != nil {
+		log.Fatalf(UnsuccessfulTermSetupMsg+": %v", err)
+	}
+
+	courseMap, err := loadCourseMapFromSheets(srv, spreadsheetId)
 	if err != nil {
 		log.Fatalf(UnsuccessfulCourseMapLoadMsg+": %v", err)
 	}
+	courses = courseapi.NewCourseStore(courseMap)
 }
 
 func main() {
+	setup()
+
+	switch flag.Arg(0) {
+	case "login":
+		runLogin()
+		return
+	case "logout":
+		runLogout()
+		return
+	case "init":
+		runInit(flag.Args()[1:])
+		return
+	case "doctor":
+		if !doctor() {
+			os.Exit(1)
+		}
+		return
+	}
+
+	initAutoRefresh()
+	initUndoHistory()
+	initReminderLog()
+
+	// Subcommands come after any flags, e.g. `go-sheets-cli --profile work serve`
+	if subcommand := flag.Args(); len(subcommand) > 0 {
+		switch subcommand[0] {
+		case "serve":
+			runServe(subcommand[1:])
+		case "remind":
+			runRemind(subcommand[1:])
+		case "digest":
+			digest(subcommand[1:])
+		default:
+			fmt.Printf("Unknown subcommand `%s` (expected serve, remind, digest, init, doctor, login or logout)\n", subcommand[0])
+		}
+		return
+	}
+
 	fmt.Println(WelcomeMsg)
+	fmt.Printf("Current term: %s\n", sheetName)
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
@@ -88,6 +191,9 @@ func main() {
 		input = strings.TrimSpace(input)
 
 		if strings.ToLower(input) == "exit" {
+			stopAutoRefresh()
+			stopReminderWatch()
+			waitForHooks()
 			fmt.Println("Goodbye!")
 			break
 		}
@@ -100,15 +206,77 @@ func main() {
 		switch args[0] {
 		case "info":
 			showInfo()
+		case "config":
+			if len(args) != 1 {
+				fmt.Println(ConfigCorrectUsageMsg)
+				continue
+			}
+
+			showConfig()
+		case "doctor":
+			if len(args) != 1 {
+				fmt.Println(DoctorCorrectUsageMsg)
+				continue
+			}
+
+			doctor()
+		case "login":
+			if len(args) != 1 {
+				fmt.Println(LoginCorrectUsageMsg)
+				continue
+			}
+
+			runLogin()
+		case "logout":
+			if len(args) != 1 {
+				fmt.Println(LogoutCorrectUsageMsg)
+				continue
+			}
+
+			runLogout()
+		case "share":
+			if len(args) < 2 || len(args) > 3 {
+				fmt.Println(ShareCorrectUsageMsg)
+				continue
+			}
+
+			role := ""
+			if len(args) == 3 {
+				role = args[2]
+			}
+			shareSpreadsheet(args[1], role)
+		case "unshare":
+			if len(args) != 2 {
+				fmt.Println(UnshareCorrectUsageMsg)
+				continue
+			}
+
+			unshareSpreadsheet(args[1])
+		case "list-shares":
+			if len(args) != 1 {
+				fmt.Println(ListSharesCorrectUsageMsg)
+				continue
+			}
+
+			listShares()
 		case "list-courses":
 			listCourses()
 		case "list-assignments":
-			if len(args) != 2 {
+			if len(args) < 2 {
 				fmt.Println(ListAssignmentsCorrectUsageMsg)
 				continue
 			}
 			courseName := args[1]
-			listAssignments(courseName)
+			listAssignments(courseName, inputAfterFields(input, 2))
+		case "find":
+			find(inputAfterFields(input, 1))
+		case "search":
+			if len(args) < 2 {
+				fmt.Println(SearchCorrectUsageMsg)
+				continue
+			}
+
+			search(inputAfterFields(input, 1))
 		case "create-course":
 			args = strings.SplitN(input, " ", 3)
 
@@ -129,6 +297,9 @@ func main() {
 
 				fmt.Printf("Unable to successfully create course `%s`\n", courseName)
 			} else {
+				newCourse, _ := courses.Get(courseName)
+				recordMutation(input, courseCreated(newCourse))
+
 				fmt.Printf("Course `%s` successfully created!\n", courseName)
 			}
 		case "create-assignment":
@@ -139,23 +310,26 @@ func main() {
 			courseName := args[1]
 			assignmentName := args[2]
 
-			_, exists := courseMap[courseName]
-			if !exists {
+			if !courses.Exists(courseName) {
 				fmt.Println(AssignmentCourseDoesntExistMsg)
 				continue
 			}
 
-			fmt.Println(AssignmentInfoMsg)
+			fmt.Printf(AssignmentInfoMsg+"\n", courseapi.DatePattern())
 			fmt.Print("> ")
 			input, _ := reader.ReadString('\n')
 			input = strings.TrimSpace(input)
 
 			args = strings.SplitN(input, " ", 2)
 			if len(args) != 1 && len(args) != 2 {
-				fmt.Println(CreateAssignmentCorrectFieldsMsg)
+				fmt.Printf(CreateAssignmentCorrectFieldsMsg+"\n", courseapi.DatePattern())
 				continue
 			} else {
-				courseItem := courseMap[courseName]
+				courseItem, exists := courses.Get(courseName)
+				if !exists {
+					fmt.Println(AssignmentCourseDoesntExistMsg)
+					continue
+				}
 				copy := courseItem.DeepCopy()
 
 				var err error
@@ -171,14 +345,14 @@ func main() {
 
 					fmt.Printf("Unable to successfully add assignment `%s` to course `%s`\n", assignmentName, courseName)
 				} else {
-					err := updateCourseRow(srv, copy)
+					err := commitCourseUpdate(fmt.Sprintf("create-assignment %s %s", courseName, assignmentName), courseItem, copy)
 
 					if err != nil {
 						log.Printf(UnsuccessfulAssignmentCreationMsg+": %v", err)
 
 						fmt.Printf("Unable to successfully create assignment `%s`\n", assignmentName)
 					} else {
-						courseMap[courseName] = &copy
+						recordMutation(fmt.Sprintf("create-assignment %s %s", courseName, assignmentName), courseUpdated(courseItem, copy))
 
 						fmt.Printf("Assignment `%s` successfully created!\n", assignmentName)
 					}
@@ -191,21 +365,21 @@ func main() {
 			}
 
 			courseName := args[1]
-			_, exists := courseMap[courseName]
+			courseItem, exists := courses.Get(courseName)
 			if !exists {
 				fmt.Println(RemovalCourseDoesntExistMsg)
 				continue
 			}
 
-			err := removeCourseRow(courseName)
+			err := commitCourseRemoval(input, courseItem)
 			if err != nil {
 				log.Printf(UnsuccessfulCourseRemovalMsg+": %v", err)
 
 				fmt.Printf("Unable to successfully remove course `%s`\n", courseName)
 			} else {
-				delete(courseMap, courseName)
+				recordMutation(input, courseRemoved(courseItem))
 
-				fmt.Printf("Course `%s` successfully removed!\n", courseName)
+				fmt.Printf("Course `%s` successfully removed! (use `undo` to restore it)\n", courseName)
 			}
 
 		case "remove-assignment":
@@ -222,7 +396,7 @@ func main() {
 				continue
 			}
 
-			courseItem, exists := courseMap[courseName]
+			courseItem, exists := courses.Get(courseName)
 			if !exists {
 				fmt.Println(AssignmentRemovalCourseDoesntExistMsg)
 				continue
@@ -236,17 +410,294 @@ func main() {
 				continue
 			}
 
-			err = updateCourseRow(srv, copy)
+			err = commitCourseUpdate(input, courseItem, copy)
 
 			if err != nil {
 				log.Printf(UnsuccessfulAssignmentRemovalMsg+": %v", err)
 
 				fmt.Printf("Unable to successfully remove assignment number `%d`\n", removeIndex)
 			} else {
-				courseMap[courseName] = &copy
+				recordMutation(input, courseUpdated(courseItem, copy))
+
+				fmt.Printf("Assignment number `%d` successfully removed! (use `undo` to restore it)\n", removeIndex)
+			}
+		case "import-csv":
+			if len(args) != 2 {
+				fmt.Println(ImportCSVCorrectUsageMsg)
+				continue
+			}
+
+			importCSV(args[1])
+		case "export-csv":
+			if len(args) != 2 && len(args) != 3 {
+				fmt.Println(ExportCSVCorrectUsageMsg)
+				continue
+			}
+
+			exportCSV(args[1], args[2:]...)
+		case "refresh":
+			refresh()
+		case "auto-refresh":
+			if len(args) != 2 {
+				fmt.Println(AutoRefreshCorrectUsageMsg)
+				continue
+			}
 
-				fmt.Printf("Assignment number `%d` successfully removed!\n", removeIndex)
+			autoRefresh(args[1])
+		case "hooks":
+			if len(args) != 1 {
+				fmt.Println(HooksCorrectUsageMsg)
+				continue
+			}
+
+			showHooks()
+		case "history":
+			if len(args) > 2 {
+				fmt.Println(HistoryCorrectUsageMsg)
+				continue
+			}
+
+			courseName := ""
+			if len(args) == 2 {
+				courseName = args[1]
 			}
+			showHistory(courseName)
+		case "set-category":
+			if len(args) != 4 {
+				fmt.Println(SetCategoryCorrectUsageMsg)
+				continue
+			}
+
+			setCategory(input, args[1], args[2], args[3])
+		case "remove-category":
+			if len(args) != 3 {
+				fmt.Println(RemoveCategoryCorrectUsageMsg)
+				continue
+			}
+
+			removeCategory(input, args[1], args[2])
+		case "set-points":
+			if len(args) != 4 && len(args) != 5 {
+				fmt.Println(SetPointsCorrectUsageMsg)
+				continue
+			}
+
+			setPoints(input, args[1], args[2], args[3], args[4:]...)
+		case "record-score":
+			if len(args) != 4 {
+				fmt.Println(RecordScoreCorrectUsageMsg)
+				continue
+			}
+
+			recordScore(input, args[1], args[2], args[3])
+		case "grade":
+			if len(args) != 2 {
+				fmt.Println(GradeCorrectUsageMsg)
+				continue
+			}
+
+			showGrade(args[1])
+		case "set-cutoffs":
+			if len(args) < 3 {
+				fmt.Println(SetCutoffsCorrectUsageMsg)
+				continue
+			}
+
+			setCutoffs(input, args[1], args[2:])
+		case "what-if":
+			if len(args) < 2 {
+				fmt.Println(WhatIfCorrectUsageMsg)
+				continue
+			}
+
+			whatIf(args[1], args[2:])
+		case "need":
+			if len(args) < 4 {
+				fmt.Println(NeedCorrectUsageMsg)
+				continue
+			}
+
+			need(args[1], args[2], args[3], args[4:])
+		case "add-tag":
+			if len(args) < 4 {
+				fmt.Println(AddTagCorrectUsageMsg)
+				continue
+			}
+
+			addTags(input, args[1], args[2], args[3:])
+		case "remove-tag":
+			if len(args) != 4 {
+				fmt.Println(RemoveTagCorrectUsageMsg)
+				continue
+			}
+
+			removeTag(input, args[1], args[2], args[3])
+		case "tags":
+			if len(args) > 2 {
+				fmt.Println(TagsCorrectUsageMsg)
+				continue
+			}
+
+			courseName := ""
+			if len(args) == 2 {
+				courseName = args[1]
+			}
+			showTags(courseName)
+		case "add-subtask":
+			if len(args) < 4 {
+				fmt.Println(AddSubtaskCorrectUsageMsg)
+				continue
+			}
+
+			name, due := parseSubtaskArgs(inputAfterFields(input, 3))
+			addSubtask(input, args[1], args[2], name, due...)
+		case "remove-subtask":
+			if len(args) != 4 {
+				fmt.Println(RemoveSubtaskCorrectUsageMsg)
+				continue
+			}
+
+			removeSubtask(input, args[1], args[2], args[3])
+		case "check", "uncheck":
+			if len(args) != 4 {
+				fmt.Println(CheckCorrectUsageMsg)
+				continue
+			}
+
+			checkSubtask(input, args[1], args[2], args[3], args[0] == "check")
+		case "add-prereq":
+			if len(args) != 5 {
+				fmt.Println(AddPrereqCorrectUsageMsg)
+				continue
+			}
+
+			addPrereq(input, args[1], args[2], args[3], args[4])
+		case "remove-prereq":
+			if len(args) != 4 {
+				fmt.Println(RemovePrereqCorrectUsageMsg)
+				continue
+			}
+
+			removePrereq(input, args[1], args[2], args[3])
+		case "deps":
+			if len(args) > 2 {
+				fmt.Println(DepsCorrectUsageMsg)
+				continue
+			}
+
+			courseName := ""
+			if len(args) == 2 {
+				courseName = args[1]
+			}
+			showDependencyWarnings(courseName)
+		case "set-effort":
+			if len(args) != 4 {
+				fmt.Println(SetEffortCorrectUsageMsg)
+				continue
+			}
+
+			setEffort(input, args[1], args[2], args[3])
+		case "workload":
+			workload(args[1:])
+		case "availability":
+			availability(inputAfterFields(input, 1))
+		case "plan":
+			if len(args) > 2 {
+				fmt.Println(PlanCorrectUsageMsg)
+				continue
+			}
+
+			icsPath := ""
+			if len(args) == 2 {
+				icsPath = args[1]
+			}
+			plan(icsPath)
+		case "digest":
+			digest(args[1:])
+		case "set-reminders":
+			if len(args) < 4 {
+				fmt.Println(SetRemindersCorrectUsageMsg)
+				continue
+			}
+
+			setReminders(input, args[1], args[2], inputAfterFields(input, 3))
+		case "default-reminders":
+			defaultReminders(inputAfterFields(input, 1))
+		case "reminders":
+			if len(args) > 1 && args[1] == "watch" {
+				if len(args) != 3 {
+					fmt.Println(RemindersCorrectUsageMsg)
+					continue
+				}
+
+				watchReminders(args[2])
+				continue
+			}
+
+			if len(args) > 2 {
+				fmt.Println(RemindersCorrectUsageMsg)
+				continue
+			}
+
+			daysArg := ""
+			if len(args) == 2 {
+				daysArg = args[1]
+			}
+			showReminders(daysArg)
+		case "course-info":
+			if len(args) < 2 {
+				fmt.Println(CourseInfoCorrectUsageMsg)
+				continue
+			}
+
+			courseInfo(input, args[1], args[2:])
+		case "timetable":
+			if len(args) != 1 {
+				fmt.Println(TimetableCorrectUsageMsg)
+				continue
+			}
+
+			timetable()
+		case "backup":
+			backup()
+		case "list-backups":
+			listBackups()
+		case "restore":
+			if len(args) != 2 {
+				fmt.Println(RestoreCorrectUsageMsg)
+				continue
+			}
+
+			restore(args[1], reader)
+		case "list-terms":
+			if len(args) > 2 || (len(args) == 2 && args[1] != "all") {
+				fmt.Println(ListTermsCorrectUsageMsg)
+				continue
+			}
+
+			listTerms(len(args) == 2)
+		case "create-term", "use-term", "archive-term", "unarchive-term":
+			// Term names such as "Fall 2026" may contain spaces
+			termName := strings.TrimSpace(strings.TrimPrefix(input, args[0]))
+			if termName == "" {
+				fmt.Printf("Usage: %s <term_name>\n", args[0])
+				continue
+			}
+
+			switch args[0] {
+			case "create-term":
+				createTerm(termName)
+			case "use-term":
+				useTerm(termName)
+			case "archive-term":
+				setTermArchived(termName, true)
+			case "unarchive-term":
+				setTermArchived(termName, false)
+			}
+		case "undo":
+			undo()
+		case "redo":
+			redo()
 		default:
 			fmt.Println("Command not recognized")
 		}
@@ -255,7 +706,12 @@ func main() {
 }
 
 func initLog() (*os.File, error) {
-	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	err := os.MkdirAll(filepath.Dir(settings.LogFile), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(settings.LogFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return nil, err
 	}
@@ -265,61 +721,58 @@ func initLog() (*os.File, error) {
 
 func getSheetsService() (*Service, error) {
 	ctx := context.Background()
-	srv, err := sheets.NewService(ctx, option.WithCredentialsFile("service-account.json"))
+	srv, err := sheets.NewService(ctx, authOption())
 
 	return srv, err
 }
 
-func createSpreadsheet(srv *sheets.Service, title string) string {
-	spreadsheet := &sheets.Spreadsheet{
-		Properties: &sheets.SpreadsheetProperties{
-			Title: title,
-		},
+// Sets key in the .env file, replacing any lines that already set it, and in the environment
+func setEnvValue(key, value string) error {
+	envFile := settings.EnvFile
+	data, err := os.ReadFile(envFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
 	}
 
-	resp, err := srv.Spreadsheets.Create(spreadsheet).Do()
-	if err != nil {
-		log.Fatalf("Unable to create spreadsheet: %v", err)
+	var lines []string
+	if len(data) > 0 {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
 	}
 
-	log.Println("New Spreadsheet Created!")
-	log.Println("Spreadsheet Title:", resp.Properties.Title)
-	log.Println("Spreadsheet ID:", resp.SpreadsheetId)
-
-	saveToEnv("SPREADSHEET_ID", resp.SpreadsheetId)
-
-	return resp.SpreadsheetId
-}
-
-func saveToEnv(key, value string) {
-	envFile := ".env"
-	f, err := os.OpenFile(envFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Fatalf("Unable to write to .env file: %v", err)
+	// Only the first line setting key is kept, which also tidies up duplicates written by older versions
+	line := fmt.Sprintf("%s=%s", key, value)
+	replaced := false
+	kept := lines[:0]
+	for _, existing := range lines {
+		if strings.HasPrefix(strings.TrimSpace(existing), key+"=") {
+			if replaced {
+				continue
+			}
+			existing = line
+			replaced = true
+		}
+		kept = append(kept, existing)
+	}
+	lines = kept
+	if !replaced {
+		lines = append(lines, line)
 	}
-	defer f.Close()
 
-	_, err = f.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+	err = os.MkdirAll(filepath.Dir(envFile), 0755)
 	if err != nil {
-		log.Fatalf("Failed to write .env variable: %v", err)
+		return err
 	}
-}
 
-func getOrCreateSpreadsheet(srv *sheets.Service, title string) string {
-	err := godotenv.Load(".env")
-	if err == nil {
-		if sheetID, exists := os.LookupEnv("SPREADSHEET_ID"); exists {
-			log.Println("Using existing Spreadsheet ID:", sheetID)
-			return sheetID
-		}
+	err = os.WriteFile(envFile, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	if err != nil {
+		return err
 	}
 
-	log.Println("No existing sheet found. Creating a new one...")
-	return createSpreadsheet(srv, title)
+	return os.Setenv(key, value)
 }
 
 func loadCourseMapFromSheets(srv *sheets.Service, spreadsheetId string) (CourseMap, error) {
-	readRange := fmt.Sprintf("%s!A:B", sheetName) // A: Course Name, B: Course JSON
+	readRange := courseapi.TabRange(sheetName, "A:B") // A: Course Name, B: Course JSON
 	resp, err := srv.Spreadsheets.Values.Get(spreadsheetId, readRange).Do()
 	if err != nil {
 		return nil, fmt.Errorf("unable to read data: %v", err)
@@ -350,7 +803,7 @@ func loadCourseMapFromSheets(srv *sheets.Service, spreadsheetId string) (CourseM
 }
 
 func findCourseRow(srv *sheets.Service, courseName string) (string, error) {
-	rangeToSearch := fmt.Sprintf("%s!A:A", sheetName)
+	rangeToSearch := courseapi.TabRange(sheetName, "A:A")
 	resp, err := srv.Spreadsheets.Values.Get(spreadsheetId, rangeToSearch).Do()
 	if err != nil {
 		return "", fmt.Errorf("unable to retrieve data: %v", err)
@@ -358,7 +811,7 @@ func findCourseRow(srv *sheets.Service, courseName string) (string, error) {
 
 	for i, row := range resp.Values {
 		if len(row) > 0 && row[0] == courseName {
-			return fmt.Sprintf("%s!A%d:B%d", sheetName, i+1, i+1), nil
+			return courseapi.TabRange(sheetName, fmt.Sprintf("A%d:B%d", i+1, i+1)), nil
 		}
 	}
 	return "", fmt.Errorf("course not found")
@@ -391,6 +844,96 @@ func updateCourseRow(srv *sheets.Service, updatedCourse CourseItem) error {
 	return nil
 }
 
+// Writes several courses in a single batch request so they are either all updated or none are
+func updateCourseRows(srv *sheets.Service, updatedCourses CourseMap) error {
+	rangeToSearch := courseapi.TabRange(sheetName, "A:A")
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetId, rangeToSearch).Do()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve data: %v", err)
+	}
+
+	rows := make(map[string]int)
+	for i, row := range resp.Values {
+		if len(row) > 0 {
+			if name, ok := row[0].(string); ok {
+				rows[name] = i + 1
+			}
+		}
+	}
+
+	var data []*sheets.ValueRange
+	for _, course := range updatedCourses {
+		rowNum, exists := rows[course.Name]
+		if !exists {
+			return fmt.Errorf("unable to find course `%s` to update", course.Name)
+		}
+
+		jsonData, err := json.Marshal(course)
+		if err != nil {
+			return fmt.Errorf("failed to encode CourseItem to JSON: %v", err)
+		}
+
+		data = append(data, &sheets.ValueRange{
+			Range:  courseapi.TabRange(sheetName, fmt.Sprintf("A%d:B%d", rowNum, rowNum)),
+			Values: [][]interface{}{{course.Name, string(jsonData)}},
+		})
+	}
+
+	_, err = srv.Spreadsheets.Values.BatchUpdate(spreadsheetId, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "RAW",
+		Data:             data,
+	}).Do()
+	if err != nil {
+		return fmt.Errorf("failed to update courses: %v", err)
+	}
+	return nil
+}
+
+// Creates the tab with the given header row if the spreadsheet doesn't have it yet.
+// Hidden tabs don't show up in the Google Sheets tab bar.
+func ensureSheetTab(srv *sheets.Service, title string, header []interface{}, hidden bool) error {
+	ensuredTabsMu.Lock()
+	defer ensuredTabsMu.Unlock()
+
+	if ensuredTabs[title] {
+		return nil
+	}
+
+	resp, err := srv.Spreadsheets.Get(spreadsheetId).Fields("sheets.properties.title").Do()
+	if err != nil {
+		return fmt.Errorf("unable to read spreadsheet tabs: %v", err)
+	}
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties.Title == title {
+			ensuredTabs[title] = true
+			return nil
+		}
+	}
+
+	_, err = srv.Spreadsheets.BatchUpdate(spreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: title, Hidden: hidden}}},
+		},
+	}).Do()
+	if err != nil {
+		return fmt.Errorf("unable to create `%s` tab: %v", title, err)
+	}
+
+	if header != nil {
+		_, err = srv.Spreadsheets.Values.Update(spreadsheetId, courseapi.TabRange(title, "A1"), &sheets.ValueRange{
+			Values: [][]interface{}{header},
+		}).ValueInputOption("RAW").Do()
+		if err != nil {
+			return fmt.Errorf("unable to write `%s` header: %v", title, err)
+		}
+	}
+
+	log.Printf("Created `%s` tab", title)
+	ensuredTabs[title] = true
+	return nil
+}
+
 func removeCourseRow(courseName string) error {
 	row, err := findCourseRow(srv, courseName)
 	if err != nil {
@@ -416,14 +959,45 @@ create-course <course_name>
     
 create-assignment <course_name> <assignment_name>
     - User will be prompted for other info, such as:
-        - due_date (required)
+        - due_date (required, {date_format})
         - info (optional notes)
     
 list-courses
     - Lists all available courses
+
+config
+    - Shows the config file, the active profile and the settings in effect (auth, credentials, spreadsheet,
+      tab, time zone, date format, log and .env file)
+
+doctor
+    - Checks the credentials, login, access to the spreadsheet and the layout of its tabs and rows,
+      explaining anything wrong and how to fix it
+
+login
+    - Logs in to Google in the browser when the profile's auth is oauth, replacing any earlier login
+
+logout
+    - Forgets and revokes the profile's Google login
+
+share <email> [reader|writer]
+    - Gives a Google account access to the spreadsheet (reader by default), or changes its role
+
+list-shares
+    - Shows the spreadsheet's link and everyone it's shared with
+
+unshare <email>
+    - Takes away a Google account's access to the spreadsheet
     
-list-assignments <course_name>
-    - Lists all assignments for the specified course
+list-assignments <course_name> [<filter>]
+    - Lists all assignments for the specified course, or only those matching a filter (see find)
+
+find <filter>
+    - Lists matching assignments across every course, soonest due first
+    - Filters combine terms that must all match, e.g. due<7d course:CS* status:open tag:exam text:"midterm"
+        - course:<pattern> (* and ? wildcards), tag:<tag>, category:<name>
+        - status:open|overdue|graded
+        - due<, due<=, due=, due>= or due> a date ({date_format}), today, or Nd/Nw from today
+        - text:<words> or bare words search names and info; quote values with spaces
     
 remove-course <course_number>
     - Removes the course at the specified 1-based index
@@ -432,20 +1006,177 @@ remove-assignment <assignment_number>
     - Removes the assignment at the specified 1-based index
     - Use the indices provided in the list-courses or list-assignments commands
 
+import-csv <file_path>
+    - Adds every assignment in a CSV with course, name, due_date and optional info columns
+    - Nothing is imported if any line fails validation
+
+export-csv <file_path> [<course_name>]
+    - Writes assignments (of every course, or only the given one) to a CSV
+
+refresh
+    - Reloads courses from the sheet and lists anything changed remotely
+
+auto-refresh <interval>|off
+    - Refreshes in the background every interval (e.g. 30s, 5m), or stops doing so
+
+history [<course_name>]
+    - Shows who changed what and when, for every course or only the given one
+
+hooks
+    - Lists the executables in the hooks directory that run for each event (assignment created, updated,
+      removed, due soon or overdue); each receives the event as JSON on stdin
+
+set-category <course_name> <category_name> <weight>
+    - Adds a weighted grading category (e.g. Exams 60), or changes its weight
+
+remove-category <course_name> <category_name>
+    - Removes a grading category no assignment uses anymore
+
+set-points <course_name> <assignment_number> <points_possible> [<category_name>]
+    - Sets what an assignment is worth and, optionally, its grading category
+
+record-score <course_name> <assignment_number> <points_earned>|-
+    - Records the score earned on an assignment (or clears it with -)
+
+grade <course_name>
+    - Shows the current course grade, broken down by category
+
+set-cutoffs <course_name> <letter>=<min_percent> ...|default
+    - Sets the course's letter grade cutoffs (default: A=90 B=80 C=70 D=60 F=0)
+
+what-if <course_name> [<assignment_number>=<score> ...] [assume=<percent>]
+    - Projects the grade from hypothetical scores for ungraded assignments
+    - assume=<percent> fills in every other ungraded assignment at that percentage
+
+need <course_name> <assignment_number> <letter> [assume=<percent>]
+    - Solves for the minimum score needed on an assignment to reach a letter grade
+
+search <terms>
+    - Searches course names and descriptions and assignment names and info, best matches first
+    - Words that start longer words also match them (tree finds trees), and matches are highlighted
+
+add-tag <course_name> <assignment_number> <tag> [<tag> ...]
+    - Tags an assignment (e.g. exam, group, reading, lab); tags are single, case-insensitive words
+
+remove-tag <course_name> <assignment_number> <tag>
+    - Removes a tag from an assignment
+
+tags [<course_name>]
+    - Shows how many assignments carry each tag, for the given course or every course
+
+add-subtask <course_name> <assignment_number> <subtask_name> [<due_date>]
+    - Adds a checklist item such as a milestone to an assignment, optionally due by a date ({date_format})
+    - The name can be several words, or quoted to end in something that looks like a date
+
+check|uncheck <course_name> <assignment_number> <subtask_number>
+    - Marks a subtask done (or not done); list-assignments shows each assignment's progress
+
+remove-subtask <course_name> <assignment_number> <subtask_number>
+    - Removes a subtask from an assignment
+
+add-prereq <course_name> <assignment_number> <prereq_course_name> <prereq_assignment_number>
+    - Records that an assignment can't start until another (in any course) is finished; cycles are rejected
+
+remove-prereq <course_name> <assignment_number> <prereq_number>
+    - Removes a prerequisite, numbered as shown under Needs in list-assignments
+
+deps [<course_name>]
+    - Warns about prerequisites due after the assignments needing them, or still open within 3 days of their deadline
+
+set-effort <course_name> <assignment_number> <hours>|-
+    - Estimates how many hours an assignment will take (or clears the estimate with -)
+
+workload [day|week] [<count>] [threshold=<hours>]
+    - Charts estimated effort of ungraded assignments by due day or week, from today (default: 4 weeks)
+    - Days over the threshold (default 4 hours, or WORKLOAD_THRESHOLD in .env) are flagged
+
+availability [<windows>]
+    - Shows or sets when you're free to study, e.g. MTWRF 18:00-21:00; SU 10:00-16:00
+
+plan [<ics_file_path>]
+    - Schedules work blocks for assignments with effort estimates before they're due, earliest deadline first
+      and spread across the least busy days, and optionally exports the plan as an .ics calendar
+
+digest [--dry-run]
+    - Emails a digest of every course's deadlines over the next 7 days, using the SMTP settings
+      from the config file or environment
+    - With --dry-run, prints the headers and text of the message instead of sending it
+
+set-reminders <course_name> <assignment_number> <offsets>|-
+    - Sets when to be reminded before an assignment is due, e.g. 3d,1d,2h (or goes back to the defaults with -)
+
+default-reminders [<offsets>]
+    - Shows or sets the reminders used by assignments without their own (default: 3d,1d,2h)
+
+reminders [<days>]
+    - Shows reminders that are due to fire, and those firing in the next days (default: 7)
+
+reminders watch <interval>|off
+    - Prints reminders as they fire, checking every interval (e.g. 1m); each one is only delivered once
+
+course-info <course_name> [<field> <value>]
+    - Shows the course's instructor, room, office hours, dates, meetings and links
+    - Fields: instructor|room|office-hours <value>|- , dates <start_date> <end_date>,
+      meeting <days> <start>-<end> [<room>] (e.g. MWF 10:00-10:50), link <url>,
+      remove-meeting|remove-link <number>
+
+timetable
+    - Shows every course's meetings over the week and flags any that overlap
+
+backup
+    - Saves a timestamped snapshot of every course locally and to a hidden Backups tab
+
+list-backups
+    - Lists the snapshots available to restore
+
+restore <backup_name>|<file_path>
+    - Previews how a snapshot differs from the current courses, then restores it once confirmed
+
+list-terms [all]
+    - Lists terms, marking the current one with *; archived terms are only shown with all
+
+create-term <term_name>
+    - Adds a term (e.g. Fall 2026) with its own tab of courses
+
+use-term <term_name>
+    - Switches the current term; every course command only sees the current term's courses
+
+archive-term <term_name>
+    - Hides a finished term's tab and leaves it out of list-terms
+
+unarchive-term <term_name>
+    - Brings an archived term back so it can be used again
+
+undo
+    - Reverts the most recent change to courses or assignments
+
+redo
+    - Re-applies the most recently undone change
+
 exit
     - Ends session of go-sheets`
 
-	fmt.Println(info)
+	// Dates are shown in the format they're configured to be entered in
+	fmt.Println(strings.ReplaceAll(info, "{date_format}", courseapi.DatePattern()))
 }
 
 func listCourses() {
-	fmt.Print(courseMap.String())
+	fmt.Print(courses.Snapshot().String())
 }
 
-func listAssignments(courseName string) {
-	courseItem, exists := courseMap[courseName]
+// Lists the course's assignments, only those matching filter if one is given
+func listAssignments(courseName string, filter string) {
+	courseItem, exists := courses.Get(courseName)
 
-	if exists && hasAssignments(*courseItem) {
+	if exists && filter != "" {
+		f, err := courseapi.ParseFilter(filter)
+		if err != nil {
+			fmt.Printf("Invalid filter: %v\n", err)
+			return
+		}
+
+		fmt.Printf("%s\n\nMatching assignments:\n%s\n", courseItem.String(), f.FindInCourse(&courseItem, time.Now()).String(false))
+	} else if exists && hasAssignments(courseItem) {
 		fmt.Print(courseItem.DetailedString())
 	} else if exists {
 		fmt.Println(courseItem.String())
@@ -466,7 +1197,7 @@ func addCourseToSheets(srv *sheets.Service, spreadsheetId string, course *Course
 		{course.Name, string(jsonData)},
 	}
 
-	writeRange := fmt.Sprintf("%s!A:B", sheetName)
+	writeRange := courseapi.TabRange(sheetName, "A:B")
 	resp, err := srv.Spreadsheets.Values.Append(spreadsheetId, writeRange, &sheets.ValueRange{
 		Values: values,
 	}).ValueInputOption("RAW").Do()
@@ -483,34 +1214,167 @@ func addCourseToSheets(srv *sheets.Service, spreadsheetId string, course *Course
 }
 
 func createCourse(courseName string, courseDescription string) (bool, error) {
-	_, exists := courseMap[courseName]
+	newCourse := CourseItem{Name: courseName, Course_Info: nil, Assignments: AssignmentList{}}
+	if !emptyDescription(courseDescription) {
+		newCourse.Course_Info = &courseDescription
+	}
 
-	if exists {
-		return false, errors.New(CourseAlreadyExistsErrMsg)
-	} else {
-		if emptyDescription(courseDescription) {
-			newCourse := CourseItem{Name: courseName, Course_Info: nil, Assignments: AssignmentList{}}
+	err := commitCourseCreation(fmt.Sprintf("create-course %s", courseName), newCourse)
+	if err != nil {
+		return false, err
+	}
 
-			// Try adding course to Google Sheets first
-			success, err := addCourseToSheets(srv, spreadsheetId, &newCourse)
-			if err != nil || !success {
-				return false, fmt.Errorf("failed to add course `%s` to Sheets: %v", courseName, err)
-			}
+	return true, nil
+}
 
-			courseMap[courseName] = &newCourse
-		} else {
-			newCourse := CourseItem{Name: courseName, Course_Info: &courseDescription, Assignments: AssignmentList{}}
+// Adds a new course to the store and appends its row, removing it again if the sheet write fails.
+// Like the other commit functions, a successful change is recorded in the history tab under command.
+func commitCourseCreation(command string, newCourse CourseItem) error {
+	refreshMu.RLock()
+	defer refreshMu.RUnlock()
+	commitMu.Lock()
+	defer commitMu.Unlock()
 
-			success, err := addCourseToSheets(srv, spreadsheetId, &newCourse)
-			if err != nil || !success {
-				return false, fmt.Errorf("failed to add course `%s` to Sheets: %v", courseName, err)
-			}
+	// Claim the name in the store first so two concurrent creates can't both append a row
+	err := courses.Add(newCourse)
+	if err != nil {
+		return ErrCourseAlreadyExists
+	}
+
+	success, err := addCourseToSheets(srv, spreadsheetId, &newCourse)
+	if err != nil || !success {
+		courses.Delete(newCourse.Name)
 
-			courseMap[courseName] = &newCourse
+		return fmt.Errorf("failed to add course `%s` to Sheets: %v", newCourse.Name, err)
+	}
+
+	auditChange(command, nil, &newCourse)
+	return nil
+}
+
+// Applies edit to a copy of the named course, then commits it and records it for undo.
+// Nothing is changed if edit fails.
+func editCourse(command string, courseName string, edit func(*CourseItem) error) (CourseItem, error) {
+	courseItem, exists := courses.Get(courseName)
+	if !exists {
+		return CourseItem{}, errors.New(CourseDoesntExistErrMsg)
+	}
+
+	copy := courseItem.DeepCopy()
+	err := edit(&copy)
+	if err != nil {
+		return CourseItem{}, err
+	}
+
+	err = commitCourseUpdate(command, courseItem, copy)
+	if err != nil {
+		return CourseItem{}, err
+	}
+
+	recordMutation(command, courseUpdated(courseItem, copy))
+	return copy, nil
+}
+
+// The rest of the input after its first n fields, with its original spacing and quotes
+func inputAfterFields(input string, n int) string {
+	rest := strings.TrimSpace(input)
+	for i := 0; i < n; i++ {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			return ""
 		}
-		return true, nil
+		rest = strings.TrimSpace(rest[end:])
+	}
+
+	return rest
+}
+
+// Converts a 1-based assignment number from the command line into a list index
+func parseAssignmentNumber(arg string) (int, error) {
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, errors.New(InvalidAssignmentNumberErrMsg)
+	}
+
+	return number - 1, nil
+}
+
+// Swaps updated in for original, then persists it, rolling the store back if the sheet write
+// fails. Fails without writing anything if the course changed since original was read.
+func commitCourseUpdate(command string, original CourseItem, updated CourseItem) error {
+	refreshMu.RLock()
+	defer refreshMu.RUnlock()
+	commitMu.Lock()
+	defer commitMu.Unlock()
+
+	if !courses.CompareAndSwap(original, updated) {
+		return ErrCourseChangedConcurrently
 	}
 
+	err := updateCourseRow(srv, updated)
+	if err != nil {
+		courses.CompareAndSwap(updated, original)
+		return err
+	}
+
+	auditChange(command, &original, &updated)
+	return nil
+}
+
+// Like commitCourseUpdate, but for several courses written in one batch: either every
+// course is swapped in and persisted, or none are
+func commitCourseUpdates(command string, originals CourseMap, updated CourseMap) error {
+	refreshMu.RLock()
+	defer refreshMu.RUnlock()
+	commitMu.Lock()
+	defer commitMu.Unlock()
+
+	var swapped []string
+	rollback := func() {
+		for _, name := range swapped {
+			courses.CompareAndSwap(*updated[name], *originals[name])
+		}
+	}
+
+	for name, course := range updated {
+		if !courses.CompareAndSwap(*originals[name], *course) {
+			rollback()
+			return ErrCourseChangedConcurrently
+		}
+		swapped = append(swapped, name)
+	}
+
+	err := updateCourseRows(srv, updated)
+	if err != nil {
+		rollback()
+		return err
+	}
+
+	for name, course := range updated {
+		auditChange(command, originals[name], course)
+	}
+	return nil
+}
+
+// Removes original from the store and clears its row, restoring it if the sheet write fails
+func commitCourseRemoval(command string, original CourseItem) error {
+	refreshMu.RLock()
+	defer refreshMu.RUnlock()
+	commitMu.Lock()
+	defer commitMu.Unlock()
+
+	if !courses.CompareAndDelete(original) {
+		return ErrCourseChangedConcurrently
+	}
+
+	err := removeCourseRow(original.Name)
+	if err != nil {
+		courses.Add(original)
+		return err
+	}
+
+	auditChange(command, &original, nil)
+	return nil
 }
 
 func emptyDescription(name string) bool {
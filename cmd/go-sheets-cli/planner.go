@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	PlanCorrectUsageMsg = "Usage: plan [<ics_file_path>]"
+
+	UnsuccessfulPlanExportMsg = "Unable to successfully export plan for reason"
+
+	availabilityEnvKey = "STUDY_AVAILABILITY"
+)
+
+// The configured study windows, falling back to the default if none are set or they can't be parsed
+func studyAvailability() []courseapi.AvailabilityWindow {
+	if spec, exists := os.LookupEnv(availabilityEnvKey); exists && spec != "" {
+		windows, err := courseapi.ParseAvailability(spec)
+		if err == nil {
+			return windows
+		}
+
+		log.Printf("Ignoring invalid %s `%s`: %v", availabilityEnvKey, spec, err)
+	}
+
+	windows, _ := courseapi.ParseAvailability(courseapi.DefaultAvailability)
+	return windows
+}
+
+// Shows the study windows used by plan, or replaces them when spec is given
+func availability(spec string) {
+	if spec == "" {
+		fmt.Printf("Available to study: %s\n", courseapi.AvailabilityString(studyAvailability()))
+		return
+	}
+
+	windows, err := courseapi.ParseAvailability(spec)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = setEnvValue(availabilityEnvKey, courseapi.AvailabilityString(windows))
+	if err != nil {
+		log.Printf("Unable to save availability to .env: %v", err)
+
+		fmt.Println("Unable to successfully save availability")
+		return
+	}
+
+	fmt.Printf("Available to study: %s\n", courseapi.AvailabilityString(windows))
+}
+
+// Prints a dated plan of work blocks across every course, optionally exporting it as an .ics calendar
+func plan(icsPath string) {
+	now := time.Now()
+	p := courseapi.NewPlan(courses.Snapshot(), now, studyAvailability())
+	fmt.Print(p.String())
+
+	if icsPath == "" {
+		return
+	}
+
+	f, err := os.Create(icsPath)
+	if err != nil {
+		log.Printf(UnsuccessfulPlanExportMsg+": %v", err)
+
+		fmt.Printf("Unable to create `%s`\n", icsPath)
+		return
+	}
+	defer f.Close()
+
+	err = p.WriteICS(f, now)
+	if err != nil {
+		log.Printf(UnsuccessfulPlanExportMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully write plan to `%s`\n", icsPath)
+		return
+	}
+
+	fmt.Printf("Plan of %d work block(s) exported to `%s`\n", len(p.Blocks), icsPath)
+}
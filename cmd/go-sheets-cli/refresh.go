@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	AutoRefreshCorrectUsageMsg = "Usage: auto-refresh <interval (e.g. 30s, 5m)>|off"
+	AutoRefreshInvalidMsg      = "Refresh interval must be a positive duration such as 30s or 5m"
+	AutoRefreshStoppedMsg      = "Automatic refresh stopped"
+
+	UnsuccessfulRefreshMsg = "Unable to successfully refresh courses from sheets"
+
+	refreshIntervalEnvKey = "REFRESH_INTERVAL"
+)
+
+var (
+	// Commits hold a read lock while they write to sheets, so a refresh never swaps in
+	// remote data from underneath a write that is still in flight
+	refreshMu sync.RWMutex
+
+	// Held by each commit from the store swap through the sheet write and any rollback, taken after
+	// refreshMu. Commits therefore reach the sheet in the order they changed the store, and a
+	// rollback can't overwrite a newer change.
+	commitMu sync.Mutex
+
+	autoRefreshMu   sync.Mutex
+	autoRefreshStop chan struct{}
+)
+
+// Re-reads every course from sheets, replaces the local copy and reports what changed remotely
+func refreshCourses() (courseapi.CourseMapDiff, error) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	remote, err := loadCourseMapFromSheets(srv, spreadsheetId)
+	if err != nil {
+		return courseapi.CourseMapDiff{}, err
+	}
+
+	diff := courseapi.DiffCourseMaps(courses.Snapshot(), remote)
+	courses.Reset(remote)
+
+	// Undoing would otherwise restore snapshots from before the remote changes, overwriting them
+	if !diff.IsEmpty() {
+		undoHistory.Clear()
+		saveUndoHistory()
+	}
+
+	return diff, nil
+}
+
+func refresh() {
+	diff, err := refreshCourses()
+	if err != nil {
+		log.Printf(UnsuccessfulRefreshMsg+": %v", err)
+
+		fmt.Println("Unable to successfully refresh courses")
+		return
+	}
+
+	if diff.IsEmpty() {
+		fmt.Println("Courses are up to date, no remote changes found.")
+	} else {
+		fmt.Printf("Remote changes loaded:\n%s\n", diff.String())
+	}
+}
+
+// Starts refreshing every interval in the background, replacing any previous schedule
+func startAutoRefresh(interval time.Duration) {
+	stopAutoRefresh()
+
+	autoRefreshMu.Lock()
+	defer autoRefreshMu.Unlock()
+
+	stop := make(chan struct{})
+	autoRefreshStop = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				diff, err := refreshCourses()
+				if err != nil {
+					log.Printf(UnsuccessfulRefreshMsg+": %v", err)
+					continue
+				}
+
+				if !diff.IsEmpty() {
+					// Interrupts whatever prompt is showing, so redraw it afterwards
+					fmt.Printf("\nRemote changes loaded:\n%s\n> ", diff.String())
+				}
+			}
+		}
+	}()
+
+	log.Printf("Automatic refresh every %s", interval)
+}
+
+func stopAutoRefresh() bool {
+	autoRefreshMu.Lock()
+	defer autoRefreshMu.Unlock()
+
+	if autoRefreshStop == nil {
+		return false
+	}
+
+	close(autoRefreshStop)
+	autoRefreshStop = nil
+	return true
+}
+
+func autoRefresh(setting string) {
+	if setting == "off" {
+		stopAutoRefresh()
+		fmt.Println(AutoRefreshStoppedMsg)
+		return
+	}
+
+	interval, err := time.ParseDuration(setting)
+	if err != nil || interval <= 0 {
+		fmt.Println(AutoRefreshInvalidMsg)
+		return
+	}
+
+	startAutoRefresh(interval)
+	fmt.Printf("Courses will be refreshed from sheets every %s\n", interval)
+}
+
+// Starts automatic refresh if an interval was configured in the .env file
+func initAutoRefresh() {
+	setting, exists := os.LookupEnv(refreshIntervalEnvKey)
+	if !exists {
+		return
+	}
+
+	interval, err := time.ParseDuration(setting)
+	if err != nil || interval <= 0 {
+		log.Printf("Ignoring invalid %s `%s`", refreshIntervalEnvKey, setting)
+		return
+	}
+
+	startAutoRefresh(interval)
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	SetRemindersCorrectUsageMsg     = "Usage: set-reminders <course_name> <assignment_number> <offsets (e.g. 3d,1d,2h)>|-"
+	DefaultRemindersCorrectUsageMsg = "Usage: default-reminders [<offsets (e.g. 3d,1d,2h)>]"
+	RemindersCorrectUsageMsg        = "Usage: reminders [<days>] | reminders watch <interval (e.g. 1m)>|off"
+	RemindCorrectUsageMsg           = "Usage: go-sheets-cli remind [<interval (e.g. 1m)>]"
+
+	UnsuccessfulReminderUpdateMsg = "Unable to successfully update reminders for reason"
+	UnsuccessfulReminderLogMsg    = "Unable to successfully save delivered reminders"
+
+	reminderOffsetsEnvKey = "REMINDER_OFFSETS"
+	reminderLogFileEnvKey = "REMINDER_LOG_FILE"
+	defaultReminderLog    = "reminders.json"
+
+	defaultReminderDays     = 7
+	defaultReminderInterval = time.Minute
+)
+
+var (
+	reminderLog = courseapi.NewReminderLog()
+
+	reminderWatchMu   sync.Mutex
+	reminderWatchStop chan struct{}
+)
+
+// The configured default offsets, falling back to the built-in ones if none are set or they can't be parsed
+func defaultReminderOffsets() []time.Duration {
+	if spec, exists := os.LookupEnv(reminderOffsetsEnvKey); exists && spec != "" {
+		offsets, err := courseapi.ParseReminderOffsets(spec)
+		if err == nil {
+			return offsets
+		}
+
+		log.Printf("Ignoring invalid %s `%s`: %v", reminderOffsetsEnvKey, spec, err)
+	}
+
+	offsets, _ := courseapi.ParseReminderOffsets(courseapi.DefaultReminderOffsets)
+	return offsets
+}
+
+func reminderLogPath() string {
+	if path, exists := os.LookupEnv(reminderLogFileEnvKey); exists && path != "" {
+		return path
+	}
+
+	return configFile(defaultReminderLog)
+}
+
+// Delivered reminders are recorded separately for each spreadsheet and term
+func reminderScope() string {
+	return courseapi.SheetScope(spreadsheetId, sheetName)
+}
+
+// Loads the record of reminders delivered by earlier sessions
+func initReminderLog() {
+	path := reminderLogPath()
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		log.Printf("Unable to open delivered reminders `%s`: %v", path, err)
+		return
+	}
+	defer f.Close()
+
+	loaded, err := courseapi.LoadReminderLog(f)
+	if err != nil {
+		log.Printf("Ignoring unreadable delivered reminders `%s`: %v", path, err)
+		return
+	}
+
+	reminderLog = loaded
+}
+
+func saveReminderLog() {
+	path := reminderLogPath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		log.Printf(UnsuccessfulReminderLogMsg+": %v", err)
+		return
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Printf(UnsuccessfulReminderLogMsg+": %v", err)
+		return
+	}
+	defer f.Close()
+
+	err = reminderLog.Save(f)
+	if err != nil {
+		log.Printf(UnsuccessfulReminderLogMsg+": %v", err)
+	}
+}
+
+// Gives an assignment its own reminder offsets, or goes back to the defaults with `-`
+func setReminders(command string, courseName string, numberArg string, spec string) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var offsets []time.Duration
+	if spec != "-" {
+		offsets, err = courseapi.ParseReminderOffsets(spec)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	_, err = editCourse(command, courseName, func(c *CourseItem) error {
+		return c.SetReminders(index, offsets)
+	})
+	if err != nil {
+		log.Printf(UnsuccessfulReminderUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update reminders of assignment number `%s`: %v\n", numberArg, err)
+		return
+	}
+
+	if offsets == nil {
+		fmt.Printf("Assignment number `%s` uses the default reminders (%s before it's due)\n", numberArg, courseapi.ReminderOffsetsString(defaultReminderOffsets()))
+	} else {
+		fmt.Printf("Assignment number `%s` will be reminded %s before it's due\n", numberArg, courseapi.ReminderOffsetsString(offsets))
+	}
+}
+
+// Shows the default reminder offsets, or replaces them when spec is given
+func defaultReminders(spec string) {
+	if spec == "" {
+		fmt.Printf("Default reminders: %s before an assignment is due\n", courseapi.ReminderOffsetsString(defaultReminderOffsets()))
+		return
+	}
+
+	offsets, err := courseapi.ParseReminderOffsets(spec)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = setEnvValue(reminderOffsetsEnvKey, courseapi.ReminderOffsetsString(offsets))
+	if err != nil {
+		log.Printf("Unable to save default reminders to .env: %v", err)
+
+		fmt.Println("Unable to successfully save default reminders")
+		return
+	}
+
+	fmt.Printf("Default reminders: %s before an assignment is due\n", courseapi.ReminderOffsetsString(offsets))
+}
+
+// Lists reminders that have fired but weren't delivered yet, then those firing in the next days
+func showReminders(daysArg string) {
+	days := defaultReminderDays
+	if daysArg != "" {
+		n, err := strconv.Atoi(daysArg)
+		if err != nil || n <= 0 {
+			fmt.Println(RemindersCorrectUsageMsg)
+			return
+		}
+		days = n
+	}
+
+	now := time.Now()
+	var pending, upcoming []courseapi.Reminder
+	for _, r := range courses.Snapshot().Reminders(defaultReminderOffsets(), now, time.Local) {
+		switch {
+		case !r.FireAt.After(now) && !reminderLog.Delivered(reminderScope(), r):
+			pending = append(pending, r)
+		case r.FireAt.After(now) && r.FireAt.Before(now.AddDate(0, 0, days)):
+			upcoming = append(upcoming, r)
+		}
+	}
+
+	if len(pending) == 0 && len(upcoming) == 0 {
+		fmt.Printf("No reminders in the next %d day(s).\n", days)
+		return
+	}
+
+	if len(pending) > 0 {
+		fmt.Println("Due to fire:")
+		for _, r := range pending {
+			fmt.Printf("  %s\n", r.String(now))
+		}
+	}
+
+	if len(upcoming) > 0 {
+		fmt.Printf("Firing in the next %d day(s):\n", days)
+		for _, r := range upcoming {
+			when := courseapi.FormatReminderOffset(r.Offset) + " before"
+			if r.Overdue() {
+				when = "once overdue"
+			}
+
+			fmt.Printf("  %s  %s (%s)\n", r.FireAt.Format("Mon "+courseapi.DateFormat+" 15:04"), r.String(r.FireAt), when)
+		}
+	}
+}
+
+// Returns reminders that fired since the last delivery, recording them as delivered and running their hooks
+func deliverReminders(now time.Time) []courseapi.Reminder {
+	// Read together, so switching terms can't pair one term's courses with another's record
+	refreshMu.RLock()
+	snapshot := courses.Snapshot()
+	scope := reminderScope()
+	refreshMu.RUnlock()
+
+	reminders := snapshot.Reminders(defaultReminderOffsets(), now, time.Local)
+	due := reminderLog.Deliver(scope, reminders, now)
+	reminderLog.Prune(scope, reminders)
+	saveReminderLog()
+
+	for _, r := range due {
+		if event, ok := courseapi.ReminderEvent(snapshot, r, now); ok {
+			emitEvents(event)
+		}
+	}
+
+	return due
+}
+
+// Delivers reminders every interval in the background, replacing any previous watch
+func startReminderWatch(interval time.Duration) {
+	stopReminderWatch()
+
+	reminderWatchMu.Lock()
+	defer reminderWatchMu.Unlock()
+
+	stop := make(chan struct{})
+	reminderWatchStop = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				for _, r := range deliverReminders(now) {
+					// Interrupts whatever prompt is showing, so redraw it afterwards
+					fmt.Printf("\nReminder: %s\n> ", r.String(now))
+				}
+			}
+		}
+	}()
+
+	log.Printf("Watching reminders every %s", interval)
+}
+
+func stopReminderWatch() bool {
+	reminderWatchMu.Lock()
+	defer reminderWatchMu.Unlock()
+
+	if reminderWatchStop == nil {
+		return false
+	}
+
+	close(reminderWatchStop)
+	reminderWatchStop = nil
+	return true
+}
+
+func watchReminders(setting string) {
+	if setting == "off" {
+		stopReminderWatch()
+		fmt.Println("Stopped watching reminders")
+		return
+	}
+
+	interval, err := time.ParseDuration(setting)
+	if err != nil || interval <= 0 {
+		fmt.Println(RemindersCorrectUsageMsg)
+		return
+	}
+
+	startReminderWatch(interval)
+	fmt.Printf("Reminders will be checked every %s\n", interval)
+}
+
+// Runs in the foreground, refreshing courses from sheets and printing reminders as they fire
+func runRemind(args []string) {
+	if len(args) > 1 {
+		fmt.Println(RemindCorrectUsageMsg)
+		return
+	}
+
+	interval := defaultReminderInterval
+	if len(args) == 1 {
+		parsed, err := time.ParseDuration(args[0])
+		if err != nil || parsed <= 0 {
+			fmt.Println(RemindCorrectUsageMsg)
+			return
+		}
+		interval = parsed
+	}
+
+	log.Printf("Delivering reminders every %s", interval)
+	fmt.Printf("Delivering reminders every %s (Ctrl+C to stop)\n", interval)
+
+	// Ctrl+C or SIGTERM stop delivering, and queued hooks run before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := refreshCourses(); err != nil {
+			log.Printf(UnsuccessfulRefreshMsg+": %v", err)
+		}
+
+		now := time.Now()
+		for _, r := range deliverReminders(now) {
+			fmt.Printf("%s  Reminder: %s\n", now.Format("15:04"), r.String(now))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Printf("Stopped delivering reminders")
+
+			stopAutoRefresh()
+			waitForHooks()
+			return
+		}
+	}
+}
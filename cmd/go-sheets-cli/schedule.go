@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	CourseInfoCorrectUsageMsg = `Usage: course-info <course_name> [instructor|room|office-hours <value>|-]
+       course-info <course_name> [dates <start_date> <end_date>]
+       course-info <course_name> [meeting <days> <start>-<end> [<room>]]
+       course-info <course_name> [link <url>]
+       course-info <course_name> [remove-meeting|remove-link <number>]`
+	TimetableCorrectUsageMsg = "Usage: timetable"
+
+	UnsuccessfulCourseInfoUpdateMsg = "Unable to successfully update course details for reason"
+)
+
+// Shows the course's details, or edits one of them when a field is given
+func courseInfo(command string, courseName string, args []string) {
+	if len(args) == 0 {
+		courseItem, exists := courses.Get(courseName)
+		if !exists {
+			fmt.Println(CourseDoesntExistErrMsg)
+			return
+		}
+
+		fmt.Println(courseItem.DetailsString())
+		return
+	}
+
+	field, values := args[0], args[1:]
+	if len(values) == 0 {
+		fmt.Println(CourseInfoCorrectUsageMsg)
+		return
+	}
+
+	var edit func(*CourseItem) error
+	switch field {
+	case "instructor", "room", "office-hours":
+		value := strings.Join(values, " ")
+		if value == "-" {
+			value = ""
+		}
+
+		edit = func(c *CourseItem) error {
+			return c.SetDetail(field, value)
+		}
+	case "dates":
+		if len(values) != 2 {
+			fmt.Println(CourseInfoCorrectUsageMsg)
+			return
+		}
+
+		edit = func(c *CourseItem) error {
+			return c.SetCourseDates(values[0], values[1])
+		}
+	case "meeting":
+		meeting, err := courseapi.ParseMeeting(values)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		edit = func(c *CourseItem) error {
+			return c.AddMeeting(meeting)
+		}
+	case "link":
+		if len(values) != 1 {
+			fmt.Println(CourseInfoCorrectUsageMsg)
+			return
+		}
+
+		edit = func(c *CourseItem) error {
+			return c.AddLink(values[0])
+		}
+	case "remove-meeting", "remove-link":
+		index, err := parseAssignmentNumber(values[0])
+		if err != nil || len(values) != 1 {
+			fmt.Println(CourseInfoCorrectUsageMsg)
+			return
+		}
+
+		edit = func(c *CourseItem) error {
+			if field == "remove-meeting" {
+				return c.RemoveMeeting(index)
+			}
+			return c.RemoveLink(index)
+		}
+	default:
+		fmt.Println(CourseInfoCorrectUsageMsg)
+		return
+	}
+
+	_, err := editCourse(command, courseName, edit)
+	if err != nil {
+		log.Printf(UnsuccessfulCourseInfoUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update course `%s`: %v\n", courseName, err)
+		return
+	}
+
+	fmt.Printf("Course `%s` details successfully updated!\n", courseName)
+}
+
+// Prints every course's meetings laid out over the week, flagging any that overlap
+func timetable() {
+	fmt.Print(courseapi.WeeklyTimetable(courses.Snapshot()).String())
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	SearchCorrectUsageMsg = "Usage: search <terms>"
+	NoSearchResultsMsg    = "No matches found."
+
+	searchResultLimit = 10
+)
+
+var (
+	// Rebuilt lazily whenever the course store has changed since it was built
+	searchIndex        *courseapi.SearchIndex
+	searchIndexVersion uint64
+	searchIndexMu      sync.Mutex
+)
+
+func currentSearchIndex() *courseapi.SearchIndex {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+
+	if searchIndex == nil || searchIndexVersion != courses.Version() {
+		cm, version := courses.SnapshotWithVersion()
+		searchIndex = courseapi.NewSearchIndex(cm)
+		searchIndexVersion = version
+	}
+
+	return searchIndex
+}
+
+// Bold yellow on a terminal, and asterisks when output is redirected
+func highlightMarkers() (string, string) {
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return "\033[1;33m", "\033[0m"
+	}
+
+	return "*", "*"
+}
+
+// Prints the best matches for terms among course names and info, and assignment names and info
+func search(terms string) {
+	results := currentSearchIndex().Search(terms, searchResultLimit)
+	if len(results) == 0 {
+		fmt.Println(NoSearchResultsMsg)
+		return
+	}
+
+	open, close := highlightMarkers()
+	for _, result := range results {
+		fmt.Printf("%s\n\n", result.String(open, close))
+	}
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	ServeCorrectUsageMsg = "Usage: go-sheets-cli serve [<listen_address>]"
+	defaultServeAddr     = "localhost:8080"
+
+	ServerCourseNotFoundErrMsg     = "course not found"
+	ServerAssignmentNotFoundErrMsg = "assignment not found"
+	ServerInvalidBodyErrMsg        = "request body must be valid JSON"
+	ServerMissingFieldErrMsg       = "missing required field"
+	ServerStorageErrMsg            = "unable to persist change to sheets"
+
+	UnsuccessfulServeMsg = "Server stopped unexpectedly"
+
+	// Request bodies are a course or an assignment, so anything larger is refused
+	maxRequestBodyBytes = 1 << 20
+
+	// Slow or idle clients are disconnected after these, so they can't hold connections open
+	serveReadTimeout  = 10 * time.Second
+	serveWriteTimeout = 30 * time.Second
+	serveIdleTimeout  = 2 * time.Minute
+
+	// Requests in flight when the server is stopped get this long to finish
+	serveShutdownTimeout = 10 * time.Second
+)
+
+type courseRequest struct {
+	Name        string  `json:"name"`
+	Course_Info *string `json:"course_info"`
+}
+
+type assignmentRequest struct {
+	Name    string  `json:"name"`
+	DueDate string  `json:"due_date"`
+	Info    *string `json:"info"`
+}
+
+// Assignments are addressed by the same 1-based number shown by `list-assignments`
+type assignmentResponse struct {
+	Number int `json:"number"`
+	AssignmentItem
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func newServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /courses", handleListCourses)
+	mux.HandleFunc("POST /courses", handleCreateCourse)
+	mux.HandleFunc("GET /courses/{course}", handleGetCourse)
+	mux.HandleFunc("PUT /courses/{course}", handleUpdateCourse)
+	mux.HandleFunc("DELETE /courses/{course}", handleDeleteCourse)
+
+	mux.HandleFunc("GET /courses/{course}/assignments", handleListAssignments)
+	mux.HandleFunc("POST /courses/{course}/assignments", handleCreateAssignment)
+	mux.HandleFunc("GET /courses/{course}/assignments/{number}", handleGetAssignment)
+	mux.HandleFunc("PUT /courses/{course}/assignments/{number}", handleUpdateAssignment)
+	mux.HandleFunc("DELETE /courses/{course}/assignments/{number}", handleDeleteAssignment)
+
+	return mux
+}
+
+// Serves until the listener fails or ctx is done, then lets requests in flight finish
+func serve(ctx context.Context, addr string) error {
+	log.Printf("Serving course data on http://%s", addr)
+	fmt.Printf("Serving course data on http://%s (Ctrl+C to stop)\n", addr)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newServeMux(),
+		ReadHeaderTimeout: serveReadTimeout,
+		ReadTimeout:       serveReadTimeout,
+		WriteTimeout:      serveWriteTimeout,
+		IdleTimeout:       serveIdleTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- server.ListenAndServe() }()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Stopping server on http://%s", addr)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ServerInvalidBodyErrMsg)
+		return false
+	}
+
+	return true
+}
+
+// Describes a request in the history tab, e.g. `PUT /courses/CS101`
+func requestCommand(r *http.Request) string {
+	return fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+}
+
+func assignmentResponses(assignments AssignmentList) []assignmentResponse {
+	responses := make([]assignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = assignmentResponse{Number: i + 1, AssignmentItem: assignment}
+	}
+
+	return responses
+}
+
+// Looks up the course and 1-based assignment number from the request path, writing a 404 if either is missing
+func lookupAssignment(w http.ResponseWriter, r *http.Request) (CourseItem, int, bool) {
+	course, exists := courses.Get(r.PathValue("course"))
+	if !exists {
+		writeError(w, http.StatusNotFound, ServerCourseNotFoundErrMsg)
+		return CourseItem{}, 0, false
+	}
+
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 || number > len(course.Assignments) {
+		writeError(w, http.StatusNotFound, ServerAssignmentNotFoundErrMsg)
+		return CourseItem{}, 0, false
+	}
+
+	return course, number - 1, true
+}
+
+func handleListCourses(w http.ResponseWriter, r *http.Request) {
+	snapshot := courses.Snapshot()
+
+	list := make([]CourseItem, 0, len(snapshot))
+	for _, course := range snapshot {
+		list = append(list, *course)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+func handleGetCourse(w http.ResponseWriter, r *http.Request) {
+	course, exists := courses.Get(r.PathValue("course"))
+	if !exists {
+		writeError(w, http.StatusNotFound, ServerCourseNotFoundErrMsg)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, course)
+}
+
+func handleCreateCourse(w http.ResponseWriter, r *http.Request) {
+	var req courseRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s `name`", ServerMissingFieldErrMsg))
+		return
+	}
+
+	courseDescription := ""
+	if req.Course_Info != nil {
+		courseDescription = *req.Course_Info
+	}
+
+	if courses.Exists(req.Name) {
+		writeError(w, http.StatusConflict, CourseAlreadyExistsErrMsg)
+		return
+	}
+
+	_, err := createCourse(req.Name, courseDescription)
+	if err != nil {
+		log.Printf(UnsuccessfulCourseCreationMsg+": %v", err)
+
+		writeStorageError(w, err)
+		return
+	}
+
+	course, _ := courses.Get(req.Name)
+	writeJSON(w, http.StatusCreated, course)
+}
+
+func handleUpdateCourse(w http.ResponseWriter, r *http.Request) {
+	var req courseRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	course, exists := courses.Get(r.PathValue("course"))
+	if !exists {
+		writeError(w, http.StatusNotFound, ServerCourseNotFoundErrMsg)
+		return
+	}
+
+	copy := course.DeepCopy()
+	copy.Course_Info = req.Course_Info
+	if copy.Course_Info != nil && emptyDescription(*copy.Course_Info) {
+		copy.Course_Info = nil
+	}
+
+	err := commitCourseUpdate(requestCommand(r), course, copy)
+	if err != nil {
+		log.Printf("Unable to successfully update course: %v", err)
+
+		writeStorageError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, copy)
+}
+
+func handleDeleteCourse(w http.ResponseWriter, r *http.Request) {
+	course, exists := courses.Get(r.PathValue("course"))
+	if !exists {
+		writeError(w, http.StatusNotFound, ServerCourseNotFoundErrMsg)
+		return
+	}
+
+	err := commitCourseRemoval(requestCommand(r), course)
+	if err != nil {
+		log.Printf(UnsuccessfulCourseRemovalMsg+": %v", err)
+
+		writeStorageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleListAssignments(w http.ResponseWriter, r *http.Request) {
+	course, exists := courses.Get(r.PathValue("course"))
+	if !exists {
+		writeError(w, http.StatusNotFound, ServerCourseNotFoundErrMsg)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, assignmentResponses(course.Assignments))
+}
+
+func handleGetAssignment(w http.ResponseWriter, r *http.Request) {
+	course, index, ok := lookupAssignment(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, assignmentResponse{Number: index + 1, AssignmentItem: course.Assignments[index]})
+}
+
+func (req assignmentRequest) validate(w http.ResponseWriter) bool {
+	for _, required := range []struct{ field, value string }{
+		{"name", req.Name},
+		{"due_date", req.DueDate},
+	} {
+		if required.value == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s `%s`", ServerMissingFieldErrMsg, required.field))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (req assignmentRequest) infoArgs() []string {
+	if req.Info == nil || *req.Info == "" {
+		return nil
+	}
+
+	return []string{*req.Info}
+}
+
+// Answers with a 409 if the course changed underneath the request, otherwise a 500
+func writeStorageError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrCourseChangedConcurrently), errors.Is(err, ErrCourseAlreadyExists):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, ServerStorageErrMsg)
+	}
+}
+
+// Persists an edited course copy in place of original, answering with its assignment list
+func commitAssignmentChange(w http.ResponseWriter, r *http.Request, original CourseItem, copy CourseItem, status int, failureMsg string) {
+	err := commitCourseUpdate(requestCommand(r), original, copy)
+	if err != nil {
+		log.Printf(failureMsg+": %v", err)
+
+		writeStorageError(w, err)
+		return
+	}
+
+	writeJSON(w, status, assignmentResponses(copy.Assignments))
+}
+
+func handleCreateAssignment(w http.ResponseWriter, r *http.Request) {
+	var req assignmentRequest
+	if !decodeBody(w, r, &req) || !req.validate(w) {
+		return
+	}
+
+	course, exists := courses.Get(r.PathValue("course"))
+	if !exists {
+		writeError(w, http.StatusNotFound, ServerCourseNotFoundErrMsg)
+		return
+	}
+
+	copy := course.DeepCopy()
+	_, err := copy.Assignments.AddAssignment(req.Name, req.DueDate, req.infoArgs()...)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	commitAssignmentChange(w, r, course, copy, http.StatusCreated, UnsuccessfulAssignmentCreationMsg)
+}
+
+func handleUpdateAssignment(w http.ResponseWriter, r *http.Request) {
+	var req assignmentRequest
+	if !decodeBody(w, r, &req) || !req.validate(w) {
+		return
+	}
+
+	course, index, ok := lookupAssignment(w, r)
+	if !ok {
+		return
+	}
+
+	copy := course.DeepCopy()
+	_, err := copy.Assignments.ReplaceAssignment(index, req.Name, req.DueDate, req.infoArgs()...)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	commitAssignmentChange(w, r, course, copy, http.StatusOK, "Unable to successfully update assignment for reason")
+}
+
+func handleDeleteAssignment(w http.ResponseWriter, r *http.Request) {
+	course, index, ok := lookupAssignment(w, r)
+	if !ok {
+		return
+	}
+
+	copy := course.DeepCopy()
+	_, err := copy.Assignments.RemoveAssignment(index)
+	if err != nil {
+		writeError(w, http.StatusNotFound, ServerAssignmentNotFoundErrMsg)
+		return
+	}
+
+	commitAssignmentChange(w, r, course, copy, http.StatusOK, UnsuccessfulAssignmentRemovalMsg)
+}
+
+func runServe(args []string) {
+	if len(args) > 1 {
+		fmt.Println(ServeCorrectUsageMsg)
+		return
+	}
+
+	addr := defaultServeAddr
+	if len(args) == 1 {
+		addr = args[0]
+	}
+
+	// Ctrl+C or SIGTERM stop the server, and queued hooks run before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := serve(ctx, addr)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf(UnsuccessfulServeMsg+": %v", err)
+
+		fmt.Printf("Unable to serve on `%s`: %v\n", addr, err)
+	}
+
+	stopAutoRefresh()
+	waitForHooks()
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	courseapi "go-sheets/courseapi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves a request against a store holding one course with one assignment. Only requests that
+// don't reach the sheet can be served, since there is no sheets service.
+func serveTestRequest(t *testing.T, method string, target string, body string) *httptest.ResponseRecorder {
+	course := CourseItem{Name: "CS101", Assignments: AssignmentList{}}
+	_, err := course.Assignments.AddAssignment("Lab 1", "02/02/25")
+	assert.NoError(t, err)
+
+	previous := courses
+	courses = courseapi.NewCourseStore(CourseMap{"CS101": &course})
+	t.Cleanup(func() { courses = previous })
+
+	rec := httptest.NewRecorder()
+	newServeMux().ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	return rec
+}
+
+func decodeErrorResponse(t *testing.T, rec *httptest.ResponseRecorder) string {
+	var resp errorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+	return resp.Error
+}
+
+func TestServer_GetCourse_Success(t *testing.T) {
+	rec := serveTestRequest(t, http.MethodGet, "/courses/CS101", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var course CourseItem
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&course))
+	assert.Equal(t, "CS101", course.Name)
+
+	rec = serveTestRequest(t, http.MethodGet, "/courses/CS101/assignments/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var assignment assignmentResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&assignment))
+	assert.Equal(t, 1, assignment.Number)
+	assert.Equal(t, "Lab 1", assignment.Name)
+}
+
+func TestServer_NotFound_Failure(t *testing.T) {
+	for _, target := range []string{"/courses/MATH200", "/courses/MATH200/assignments", "/courses/CS101/assignments/2", "/courses/CS101/assignments/x"} {
+		rec := serveTestRequest(t, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code, target)
+	}
+
+	rec := serveTestRequest(t, http.MethodDelete, "/courses/MATH200", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ServerCourseNotFoundErrMsg, decodeErrorResponse(t, rec))
+}
+
+func TestServer_CreateCourse_Conflict_Failure(t *testing.T) {
+	rec := serveTestRequest(t, http.MethodPost, "/courses", `{"name": "CS101"}`)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, CourseAlreadyExistsErrMsg, decodeErrorResponse(t, rec))
+}
+
+func TestServer_BadRequest_Failure(t *testing.T) {
+	rec := serveTestRequest(t, http.MethodPost, "/courses", `{"name": `)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, ServerInvalidBodyErrMsg, decodeErrorResponse(t, rec))
+
+	rec = serveTestRequest(t, http.MethodPost, "/courses", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, ServerMissingFieldErrMsg+" `name`", decodeErrorResponse(t, rec))
+
+	rec = serveTestRequest(t, http.MethodPost, "/courses/CS101/assignments", `{"name": "Lab 2", "due_date": "soon"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, decodeErrorResponse(t, rec), courseapi.InvalidDateErrMsg)
+
+	// Bodies over the limit are refused rather than read into memory
+	huge := `{"name": "` + strings.Repeat("x", maxRequestBodyBytes) + `"}`
+	rec = serveTestRequest(t, http.MethodPost, "/courses", huge)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWriteStorageError_Success(t *testing.T) {
+	for err, status := range map[error]int{
+		ErrCourseChangedConcurrently:                                      http.StatusConflict,
+		fmt.Errorf("updating CS101: %w", ErrCourseAlreadyExists):          http.StatusConflict,
+		fmt.Errorf("failed to update course: %w", http.ErrHandlerTimeout): http.StatusInternalServerError,
+	} {
+		rec := httptest.NewRecorder()
+		writeStorageError(rec, err)
+		assert.Equal(t, status, rec.Code, err.Error())
+	}
+}
+
+func TestServe_Stop_Success(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, serve(ctx, "localhost:0"))
+}
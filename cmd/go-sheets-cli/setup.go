@@ -0,0 +1,391 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+
+	courseapi "go-sheets/courseapi"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	InitCorrectUsageMsg   = "Usage: init [<spreadsheet_id_or_link>]"
+	DoctorCorrectUsageMsg = "Usage: doctor"
+	NoSpreadsheetErrMsg   = "no spreadsheet is configured, run `go-sheets-cli init` to create one or use an existing one"
+	ForeignLayoutErrMsg   = "spreadsheet doesn't look like a go-sheets spreadsheet"
+
+	UnsuccessfulSpreadsheetSetupMsg = "Unable to successfully set up spreadsheet"
+	UnsuccessfulInitMsg             = "Unable to successfully set up spreadsheet for reason"
+
+	defaultSpreadsheetTitle = "Course Tracking Sheet"
+)
+
+// The header row each of go-sheets' own tabs starts with; every other tab is a term holding courses
+var auxiliaryHeaders = map[string][]interface{}{
+	historySheetName: courseapi.HistoryHeader,
+	backupSheetName:  backupHeader,
+	termsSheetName:   termsHeader,
+}
+
+func connect() error {
+	var err error
+	srv, err = getSheetsService()
+	if err != nil {
+		return err
+	}
+
+	driveSrv, err = getDriveService()
+	return err
+}
+
+// Suggests what to do about an error from Google, or returns "" when there's nothing specific to say
+func accessHint(err error) string {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+
+	switch apiErr.Code {
+	case http.StatusUnauthorized:
+		if tokenSource != nil {
+			return "Run `login` again"
+		}
+		return "Check the credentials file is a current key for the service account"
+	case http.StatusForbidden, http.StatusNotFound:
+		if tokenSource != nil {
+			return "Check the spreadsheet ID, and that the spreadsheet is shared with the account you logged in as"
+		}
+		if email, err := courseapi.CheckCredentials(settings.Credentials, settings.Auth); err == nil {
+			return fmt.Sprintf("Check the spreadsheet ID, and share the spreadsheet with %s as an editor", email)
+		}
+	}
+
+	return ""
+}
+
+// A term tab with rows that can't be loaded, or one of go-sheets' own tabs with the wrong header
+type layoutProblem struct {
+	tab    string
+	header bool
+	msg    string
+}
+
+func (p layoutProblem) String() string {
+	return fmt.Sprintf("`%s` tab, %s", p.tab, p.msg)
+}
+
+// Reads every tab of the spreadsheet and checks it's laid out as go-sheets expects. Also returns
+// the titles of the tabs.
+func checkLayout() ([]string, []layoutProblem, error) {
+	resp, err := srv.Spreadsheets.Get(spreadsheetId).Fields("sheets.properties.title").Do()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var titles, ranges []string
+	for _, sheet := range resp.Sheets {
+		title := sheet.Properties.Title
+		titles = append(titles, title)
+
+		if _, exists := auxiliaryHeaders[title]; exists {
+			ranges = append(ranges, courseapi.TabRange(title, "1:1"))
+		} else {
+			ranges = append(ranges, courseapi.TabRange(title, "A:B"))
+		}
+	}
+
+	values, err := srv.Spreadsheets.Values.BatchGet(spreadsheetId).Ranges(ranges...).Do()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var problems []layoutProblem
+	for i, title := range titles {
+		var rows [][]interface{}
+		if i < len(values.ValueRanges) {
+			rows = values.ValueRanges[i].Values
+		}
+
+		if header, exists := auxiliaryHeaders[title]; exists {
+			if err := courseapi.CheckHeader(rows, header); err != nil {
+				problems = append(problems, layoutProblem{tab: title, header: true, msg: err.Error()})
+			}
+			continue
+		}
+
+		for _, problem := range courseapi.CheckCourseRows(rows) {
+			problems = append(problems, layoutProblem{tab: title, msg: problem.String()})
+		}
+	}
+
+	return titles, problems, nil
+}
+
+func createSpreadsheet(srv *sheets.Service, title string) (string, error) {
+	spreadsheet := &sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{
+			Title: title,
+		},
+	}
+
+	resp, err := srv.Spreadsheets.Create(spreadsheet).Do()
+	if err != nil {
+		return "", err
+	}
+
+	log.Println("New Spreadsheet Created!")
+	log.Println("Spreadsheet Title:", resp.Properties.Title)
+	log.Println("Spreadsheet ID:", resp.SpreadsheetId)
+
+	return resp.SpreadsheetId, nil
+}
+
+// Creates a spreadsheet, or attaches to the given or configured one, checks it can be used and
+// saves its ID. Running it again with the same spreadsheet changes nothing.
+func initSpreadsheet(args []string) error {
+	id := settings.SpreadsheetID
+	if len(args) == 1 {
+		parsed, err := courseapi.ParseSpreadsheetID(args[0])
+		if err != nil {
+			return err
+		}
+		id = parsed
+	}
+
+	if !loggedIn() {
+		fmt.Println("go-sheets isn't logged in to Google yet.")
+		err := login()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := connect()
+	if err != nil {
+		return err
+	}
+
+	created := id == ""
+	if created {
+		id, err = createSpreadsheet(srv, defaultSpreadsheetTitle)
+		if err != nil {
+			return fmt.Errorf("unable to create spreadsheet: %v", err)
+		}
+
+		// Saved before anything else can fail, so running init again picks the new spreadsheet up
+		// rather than creating another
+		err = saveSpreadsheetID(id)
+		if err != nil {
+			return fmt.Errorf("created spreadsheet %s but unable to save its ID: %v", spreadsheetURL(id), err)
+		}
+	}
+	spreadsheetId = id
+
+	resp, err := srv.Spreadsheets.Get(id).Fields("properties.title").Do()
+	if err != nil {
+		if hint := accessHint(err); hint != "" {
+			return fmt.Errorf("unable to open spreadsheet: %v\n%s", err, hint)
+		}
+		return fmt.Errorf("unable to open spreadsheet: %v", err)
+	}
+
+	// Checked before anything is written, so a spreadsheet that isn't go-sheets' is left alone
+	_, problems, err := checkLayout()
+	if err != nil {
+		return fmt.Errorf("unable to read spreadsheet: %v", err)
+	}
+	for _, problem := range problems {
+		if problem.header {
+			return fmt.Errorf("%s: %s", ForeignLayoutErrMsg, problem)
+		}
+	}
+
+	err = initTerm()
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("Created spreadsheet `%s`: %s\n", resp.Properties.Title, spreadsheetURL(id))
+		shareNewSpreadsheet(id)
+	} else {
+		fmt.Printf("Using spreadsheet `%s`: %s\n", resp.Properties.Title, spreadsheetURL(id))
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("%d row(s) can't be read and will be skipped; run `doctor` for details\n", len(problems))
+	}
+
+	if created {
+		return nil
+	}
+	if id == settings.SpreadsheetID {
+		fmt.Println("Spreadsheet is already configured")
+		return nil
+	}
+
+	err = saveSpreadsheetID(id)
+	if err != nil {
+		return fmt.Errorf("unable to save spreadsheet ID: %v", err)
+	}
+	return nil
+}
+
+// Remembers id as the spreadsheet to use from now on
+func saveSpreadsheetID(id string) error {
+	err := setEnvValue(spreadsheetIdEnvKey, id)
+	if err != nil {
+		return err
+	}
+	settings.SpreadsheetID = id
+
+	fmt.Printf("Saved %s to %s\n", spreadsheetIdEnvKey, settings.EnvFile)
+	return nil
+}
+
+func runInit(args []string) {
+	if len(args) > 1 {
+		fmt.Println(InitCorrectUsageMsg)
+		return
+	}
+
+	err := initSpreadsheet(args)
+	if err != nil {
+		log.Printf(UnsuccessfulInitMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully set up spreadsheet: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Prints the result of each of doctor's checks as it's made, counting failures
+type diagnosis struct {
+	failures int
+	warnings int
+}
+
+func (d *diagnosis) pass(check string, detail string) {
+	fmt.Printf("[ok]   %s: %s\n", check, detail)
+}
+
+func (d *diagnosis) warn(check string, detail string) {
+	d.warnings++
+	fmt.Printf("[warn] %s: %s\n", check, detail)
+}
+
+func (d *diagnosis) fail(check string, err error, hint string) {
+	d.failures++
+	fmt.Printf("[fail] %s: %v\n", check, err)
+	if hint != "" {
+		fmt.Printf("       %s\n", hint)
+	}
+}
+
+// Checks everything go-sheets needs in turn, stopping at the first problem later checks depend on
+func diagnose(d *diagnosis) {
+	status := ""
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		status = " (not created, using defaults)"
+	}
+	if profileName != "" {
+		status += fmt.Sprintf(", profile `%s`", profileName)
+	}
+	d.pass("Config", configPath+status)
+
+	who, err := courseapi.CheckCredentials(settings.Credentials, settings.Auth)
+	if err != nil {
+		d.fail("Credentials", err, "Download credentials from the Google Cloud console, or point the credentials setting at them (see README)")
+		return
+	}
+	if tokenSource == nil {
+		d.pass("Credentials", "service account "+who)
+	} else {
+		d.pass("Credentials", "OAuth client "+who)
+
+		if !loggedIn() {
+			d.fail("Login", errors.New(courseapi.NotLoggedInErrMsg), "")
+			return
+		}
+		if _, err := tokenSource.Token(); err != nil {
+			d.fail("Login", err, "Run `login` again")
+			return
+		}
+		d.pass("Login", "token in "+settings.TokenFile)
+	}
+
+	if settings.SpreadsheetID == "" {
+		d.fail("Spreadsheet", errors.New(NoSpreadsheetErrMsg), "")
+		return
+	}
+	spreadsheetId = settings.SpreadsheetID
+
+	if srv == nil {
+		if err := connect(); err != nil {
+			d.fail("Connection", err, "")
+			return
+		}
+	}
+
+	resp, err := srv.Spreadsheets.Get(spreadsheetId).Fields("properties.title").Do()
+	if err != nil {
+		d.fail("Spreadsheet", err, accessHint(err))
+		return
+	}
+	d.pass("Spreadsheet", fmt.Sprintf("`%s` %s", resp.Properties.Title, spreadsheetURL(spreadsheetId)))
+
+	file, err := driveSrv.Files.Get(spreadsheetId).Fields("capabilities/canEdit").Do()
+	if err != nil {
+		d.warn("Permissions", fmt.Sprintf("unable to check edit access (%v)", err))
+	} else if !file.Capabilities.CanEdit {
+		d.fail("Permissions", errors.New("spreadsheet is read-only"), "Ask its owner to share it with you as an editor")
+	} else {
+		d.pass("Permissions", "can edit")
+	}
+
+	titles, problems, err := checkLayout()
+	if err != nil {
+		d.fail("Layout", err, accessHint(err))
+		return
+	}
+
+	if slices.Contains(titles, sheetName) {
+		d.pass("Tab", fmt.Sprintf("`%s`", sheetName))
+	} else {
+		d.warn("Tab", fmt.Sprintf("`%s` doesn't exist yet, and will be created on the next run", sheetName))
+	}
+
+	for _, problem := range problems {
+		if problem.header {
+			d.fail("Layout", errors.New(problem.String()), "The tab is used by go-sheets; rename it if it holds something else")
+		} else {
+			d.fail("Rows", errors.New(problem.String()), "Fix or remove the row in Google Sheets; it's skipped when courses are loaded")
+		}
+	}
+	if len(problems) == 0 {
+		d.pass("Rows", fmt.Sprintf("every row of %d tab(s) can be read", len(titles)))
+	}
+}
+
+// Checks credentials, access to the spreadsheet and its contents, printing what's wrong and how to fix it.
+// Returns whether everything passed.
+func doctor() bool {
+	var d diagnosis
+	diagnose(&d)
+
+	switch {
+	case d.failures > 0:
+		fmt.Printf("\n%d problem(s) and %d warning(s) found\n", d.failures, d.warnings)
+	case d.warnings > 0:
+		fmt.Printf("\nNo problems found, %d warning(s)\n", d.warnings)
+	default:
+		fmt.Println("\nNo problems found")
+	}
+
+	return d.failures == 0
+}
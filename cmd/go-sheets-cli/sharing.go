@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	courseapi "go-sheets/courseapi"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+const (
+	ShareCorrectUsageMsg      = "Usage: share <email> [reader|writer]"
+	UnshareCorrectUsageMsg    = "Usage: unshare <email>"
+	ListSharesCorrectUsageMsg = "Usage: list-shares"
+
+	UnsuccessfulShareMsg      = "Unable to successfully share spreadsheet for reason"
+	UnsuccessfulUnshareMsg    = "Unable to successfully unshare spreadsheet for reason"
+	UnsuccessfulListSharesMsg = "Unable to successfully list spreadsheet shares for reason"
+)
+
+var driveSrv *drive.Service
+
+// Connects to Drive with the same credentials as sheets. Only files go-sheets created are reachable.
+func getDriveService() (*drive.Service, error) {
+	return drive.NewService(context.Background(), authOption(), option.WithScopes(drive.DriveFileScope))
+}
+
+func sharing() courseapi.Sharing {
+	return courseapi.NewSharing(driveSrv, spreadsheetId)
+}
+
+func spreadsheetURL(id string) string {
+	return fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/edit", id)
+}
+
+// Shares a spreadsheet that was just created with the addresses in the share_with setting. Failures
+// are reported but don't stop go-sheets, since sharing can be retried with `share`.
+func shareNewSpreadsheet(id string) {
+	grants, _ := courseapi.ParseGrants(settings.ShareWith)
+	if len(grants) == 0 {
+		return
+	}
+
+	s := courseapi.NewSharing(driveSrv, id)
+	for _, grant := range grants {
+		share, err := s.Share(context.Background(), grant, true)
+		if err != nil {
+			log.Printf(UnsuccessfulShareMsg+": %v", err)
+
+			fmt.Printf("Unable to successfully share the new spreadsheet with %s: %v\n", grant.Email, err)
+			continue
+		}
+
+		log.Printf("Shared new spreadsheet with %s", share)
+	}
+
+	fmt.Printf("Created a spreadsheet shared with %d address(es): %s\n", len(grants), spreadsheetURL(id))
+}
+
+func shareSpreadsheet(email string, role string) {
+	grant, err := courseapi.NewGrant(email, role)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	share, err := sharing().Share(context.Background(), grant, true)
+	if err != nil {
+		log.Printf(UnsuccessfulShareMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully share spreadsheet with %s: %v\n", email, err)
+		return
+	}
+
+	fmt.Printf("Spreadsheet shared with %s\n", share)
+	fmt.Println(spreadsheetURL(spreadsheetId))
+}
+
+func unshareSpreadsheet(email string) {
+	err := sharing().Unshare(context.Background(), email)
+	if err != nil {
+		log.Printf(UnsuccessfulUnshareMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully unshare spreadsheet with %s: %v\n", email, err)
+		return
+	}
+
+	fmt.Printf("Spreadsheet no longer shared with %s\n", email)
+}
+
+func listShares() {
+	shares, err := sharing().List(context.Background())
+	if err != nil {
+		log.Printf(UnsuccessfulListSharesMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully list who the spreadsheet is shared with: %v\n", err)
+		return
+	}
+
+	fmt.Println(spreadsheetURL(spreadsheetId))
+	for _, share := range shares {
+		fmt.Printf("- %s\n", share)
+	}
+}
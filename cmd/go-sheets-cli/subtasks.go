@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	AddSubtaskCorrectUsageMsg    = "Usage: add-subtask <course_name> <assignment_number> <subtask_name> [<due_date>]"
+	RemoveSubtaskCorrectUsageMsg = "Usage: remove-subtask <course_name> <assignment_number> <subtask_number>"
+	CheckCorrectUsageMsg         = "Usage: check|uncheck <course_name> <assignment_number> <subtask_number>"
+
+	UnsuccessfulSubtaskUpdateMsg = "Unable to successfully update subtasks for reason"
+)
+
+// Applies edit to the numbered assignment of a course, then reports its progress
+func editSubtasks(command string, courseName string, numberArg string, edit func(*CourseItem, int) error) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	updated, err := editCourse(command, courseName, func(c *CourseItem) error {
+		return edit(c, index)
+	})
+	if err != nil {
+		log.Printf(UnsuccessfulSubtaskUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update subtasks of assignment number `%s`: %v\n", numberArg, err)
+		return
+	}
+
+	fmt.Println(updated.Assignments[index].String())
+}
+
+// Splits the rest of an add-subtask command into the subtask's name and optional due date. The name
+// may be quoted, e.g. `"Write draft" 03/01/25`; otherwise a last field that is a date is the due date.
+func parseSubtaskArgs(rest string) (name string, due []string) {
+	if strings.HasPrefix(rest, `"`) {
+		if end := strings.Index(rest[1:], `"`); end >= 0 {
+			name, rest = rest[1:end+1], strings.TrimSpace(rest[end+2:])
+			if rest != "" {
+				due = []string{rest}
+			}
+			return name, due
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) > 1 {
+		last := fields[len(fields)-1]
+		if _, err := time.Parse(courseapi.DateFormat, last); err == nil {
+			return strings.TrimSpace(strings.TrimSuffix(rest, last)), []string{last}
+		}
+	}
+
+	return rest, nil
+}
+
+func addSubtask(command string, courseName string, numberArg string, name string, due ...string) {
+	editSubtasks(command, courseName, numberArg, func(c *CourseItem, index int) error {
+		return c.AddSubtask(index, name, due...)
+	})
+}
+
+func removeSubtask(command string, courseName string, numberArg string, subtaskArg string) {
+	subtask, err := parseAssignmentNumber(subtaskArg)
+	if err != nil {
+		fmt.Println(RemoveSubtaskCorrectUsageMsg)
+		return
+	}
+
+	editSubtasks(command, courseName, numberArg, func(c *CourseItem, index int) error {
+		return c.RemoveSubtask(index, subtask)
+	})
+}
+
+// Checks off a subtask, or unchecks it when done is false
+func checkSubtask(command string, courseName string, numberArg string, subtaskArg string, done bool) {
+	subtask, err := parseAssignmentNumber(subtaskArg)
+	if err != nil {
+		fmt.Println(CheckCorrectUsageMsg)
+		return
+	}
+
+	editSubtasks(command, courseName, numberArg, func(c *CourseItem, index int) error {
+		return c.SetSubtaskDone(index, subtask, done)
+	})
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSubtaskArgs_Success(t *testing.T) {
+	for rest, want := range map[string]struct {
+		name string
+		due  []string
+	}{
+		"Proposal":                     {"Proposal", nil},
+		"Write first draft":            {"Write first draft", nil},
+		"Write first draft 03/01/25":   {"Write first draft", []string{"03/01/25"}},
+		"03/01/25":                     {"03/01/25", nil},
+		`"Review 03/01/25"`:            {"Review 03/01/25", nil},
+		`"Review 03/01/25"   03/02/25`: {"Review 03/01/25", []string{"03/02/25"}},
+		`"Unterminated quote 03/01/25`: {`"Unterminated quote`, []string{"03/01/25"}},
+	} {
+		name, due := parseSubtaskArgs(rest)
+		assert.Equal(t, want.name, name, rest)
+		assert.Equal(t, want.due, due, rest)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	AddTagCorrectUsageMsg    = "Usage: add-tag <course_name> <assignment_number> <tag> [<tag> ...]"
+	RemoveTagCorrectUsageMsg = "Usage: remove-tag <course_name> <assignment_number> <tag>"
+	TagsCorrectUsageMsg      = "Usage: tags [<course_name>]"
+
+	UnsuccessfulTagUpdateMsg = "Unable to successfully update tags for reason"
+)
+
+func addTags(command string, courseName string, numberArg string, tags []string) {
+	editTags(command, courseName, numberArg, func(c *CourseItem, index int) error {
+		return c.AddTags(index, tags...)
+	})
+}
+
+func removeTag(command string, courseName string, numberArg string, tag string) {
+	editTags(command, courseName, numberArg, func(c *CourseItem, index int) error {
+		return c.RemoveTag(index, tag)
+	})
+}
+
+func editTags(command string, courseName string, numberArg string, edit func(*CourseItem, int) error) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	updated, err := editCourse(command, courseName, func(c *CourseItem) error {
+		return edit(c, index)
+	})
+	if err != nil {
+		log.Printf(UnsuccessfulTagUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update tags of assignment number `%s`: %v\n", numberArg, err)
+		return
+	}
+
+	tags := updated.Assignments[index].Tags
+	if len(tags) == 0 {
+		fmt.Printf("Assignment number `%s` no longer has any tags\n", numberArg)
+	} else {
+		fmt.Printf("Assignment number `%s` is now tagged: %v\n", numberArg, tags)
+	}
+}
+
+// Prints tag counts for the given course, or for every course in the current term
+func showTags(courseName string) {
+	if courseName != "" {
+		courseItem, exists := courses.Get(courseName)
+		if !exists {
+			fmt.Println(CourseDoesntExistErrMsg)
+			return
+		}
+
+		fmt.Println(courseapi.TagCountsString(courseItem.TagCounts()))
+		return
+	}
+
+	snapshot := courses.Snapshot()
+	if len(snapshot) == 0 {
+		fmt.Println("No courses available.")
+		return
+	}
+
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s: %s\n", name, courseapi.TagCountsString(snapshot[name].TagCounts()))
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	courseapi "go-sheets/courseapi"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	ListTermsCorrectUsageMsg = "Usage: list-terms [all]"
+	ReservedTermNameErrMsg   = "that name is already used by another tab"
+	ArchiveCurrentTermErrMsg = "the current term can't be archived, switch to another term first"
+	ArchivedTermErrMsg       = "term is archived, unarchive it first"
+
+	UnsuccessfulTermSetupMsg  = "Unable to successfully set up the current term"
+	UnsuccessfulTermLoadMsg   = "Unable to successfully load terms"
+	UnsuccessfulTermUpdateMsg = "Unable to successfully update terms for reason"
+
+	termsSheetName    = "Terms"
+	currentTermEnvKey = "CURRENT_TERM"
+
+	// Courses lived in this tab before they were organized into terms, so it stays the default
+	defaultTermName = "Sheet1"
+)
+
+var termsHeader = []interface{}{"term", "archived"}
+
+// Switches to the configured term, creating its tab if needed
+func initTerm() error {
+	sheetName = settings.Tab
+
+	return ensureSheetTab(srv, sheetName, nil, false)
+}
+
+// Whether a history entry or backup recorded under term belongs to the current term.
+// Records made before terms existed belong to the default term.
+func inCurrentTerm(term string) bool {
+	if term == "" {
+		term = defaultTermName
+	}
+
+	return term == sheetName
+}
+
+// Reads the hidden terms tab. The current term is always included, even if it was never
+// added there (as with the default term).
+func loadTerms(srv *sheets.Service) (courseapi.TermList, error) {
+	err := ensureSheetTab(srv, termsSheetName, termsHeader, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetId, courseapi.TabRange(termsSheetName, "A2:B")).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read terms: %v", err)
+	}
+
+	terms := courseapi.TermListFromRows(resp.Values)
+	if _, exists := terms.Find(sheetName); !exists {
+		terms = append(courseapi.TermList{{Name: sheetName}}, terms...)
+	}
+
+	return terms, nil
+}
+
+// Writes the terms below the header in one update, so a failure can't leave the tab empty
+func saveTerms(srv *sheets.Service, terms courseapi.TermList) error {
+	err := courseapi.ReplaceRows(context.Background(), srv, spreadsheetId, termsSheetName, 2, terms.Rows())
+	if err != nil {
+		return fmt.Errorf("failed to write terms: %v", err)
+	}
+
+	return nil
+}
+
+// Shows or hides a tab in the Google Sheets tab bar
+func setTabHidden(srv *sheets.Service, title string, hidden bool) error {
+	resp, err := srv.Spreadsheets.Get(spreadsheetId).Fields("sheets.properties(sheetId,title)").Do()
+	if err != nil {
+		return fmt.Errorf("unable to read spreadsheet tabs: %v", err)
+	}
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties.Title != title {
+			continue
+		}
+
+		_, err = srv.Spreadsheets.BatchUpdate(spreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+					Properties: &sheets.SheetProperties{SheetId: sheet.Properties.SheetId, Hidden: hidden, ForceSendFields: []string{"Hidden"}},
+					Fields:     "hidden",
+				}},
+			},
+		}).Do()
+		if err != nil {
+			return fmt.Errorf("unable to update `%s` tab: %v", title, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no `%s` tab found", title)
+}
+
+func listTerms(includeArchived bool) {
+	terms, err := loadTerms(srv)
+	if err != nil {
+		log.Printf(UnsuccessfulTermLoadMsg+": %v", err)
+
+		fmt.Println("Unable to successfully load terms")
+		return
+	}
+
+	fmt.Print(terms.String(sheetName, includeArchived))
+}
+
+func createTerm(termName string) {
+	err := addTerm(termName)
+	if err != nil {
+		log.Printf(UnsuccessfulTermUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully create term `%s`: %v\n", termName, err)
+		return
+	}
+
+	fmt.Printf("Term `%s` successfully created! Use `use-term %s` to switch to it.\n", termName, termName)
+}
+
+func addTerm(termName string) error {
+	// Tab titles are case-insensitive, so `history` would collide with the History tab
+	for _, reserved := range []string{historySheetName, backupSheetName, termsSheetName} {
+		if strings.EqualFold(termName, reserved) {
+			return errors.New(ReservedTermNameErrMsg)
+		}
+	}
+
+	terms, err := loadTerms(srv)
+	if err != nil {
+		return err
+	}
+
+	err = terms.Add(termName)
+	if err != nil {
+		return err
+	}
+
+	err = ensureSheetTab(srv, termName, nil, false)
+	if err != nil {
+		return err
+	}
+
+	return saveTerms(srv, terms)
+}
+
+func useTerm(termName string) {
+	if termName == sheetName {
+		fmt.Printf("Already using term `%s`\n", termName)
+		return
+	}
+
+	terms, err := loadTerms(srv)
+	if err != nil {
+		log.Printf(UnsuccessfulTermLoadMsg+": %v", err)
+
+		fmt.Println("Unable to successfully load terms")
+		return
+	}
+
+	term, exists := terms.Find(termName)
+	if !exists {
+		fmt.Printf("Unable to switch to `%s`: %s (use `create-term` to add it)\n", termName, courseapi.TermNotFoundErrMsg)
+		return
+	} else if term.Archived {
+		fmt.Printf("Unable to switch to `%s`: %s\n", termName, ArchivedTermErrMsg)
+		return
+	}
+
+	err = switchTerm(termName)
+	if err != nil {
+		log.Printf(UnsuccessfulTermUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully switch to term `%s`\n", termName)
+		return
+	}
+
+	fmt.Printf("Now using term `%s` (%d courses)\n", termName, courses.Len())
+}
+
+// Loads the term's courses in place of the current ones and remembers it as the current term.
+// Undo history switches to the term's own, since the previous term's refers to other courses.
+func switchTerm(termName string) error {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	previous := sheetName
+	sheetName = termName
+
+	courseMap, err := loadCourseMapFromSheets(srv, spreadsheetId)
+	if err != nil {
+		sheetName = previous
+		return err
+	}
+	courses.Reset(courseMap)
+
+	err = setEnvValue(currentTermEnvKey, termName)
+	if err != nil {
+		log.Printf("Unable to save current term to .env, it will reset next session: %v", err)
+	}
+
+	initUndoHistory()
+
+	return nil
+}
+
+// Archives or unarchives a term, hiding its tab while it is archived
+func setTermArchived(termName string, archived bool) {
+	verb := "archive"
+	if !archived {
+		verb = "unarchive"
+	}
+
+	err := updateTermArchived(termName, archived)
+	if err != nil {
+		log.Printf(UnsuccessfulTermUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully %s term `%s`: %v\n", verb, termName, err)
+		return
+	}
+
+	fmt.Printf("Term `%s` successfully %sd!\n", termName, verb)
+}
+
+func updateTermArchived(termName string, archived bool) error {
+	if archived && termName == sheetName {
+		return errors.New(ArchiveCurrentTermErrMsg)
+	}
+
+	terms, err := loadTerms(srv)
+	if err != nil {
+		return err
+	}
+
+	err = terms.SetArchived(termName, archived)
+	if err != nil {
+		return err
+	}
+
+	err = setTabHidden(srv, termName, archived)
+	if err != nil {
+		return err
+	}
+
+	return saveTerms(srv, terms)
+}
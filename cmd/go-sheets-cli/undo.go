@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	UndoCourseExistsErrMsg = "course to restore already exists"
+
+	UnsuccessfulUndoMsg        = "Unable to successfully undo for reason"
+	UnsuccessfulRedoMsg        = "Unable to successfully redo for reason"
+	UnsuccessfulUndoHistoryMsg = "Unable to successfully save undo history"
+
+	undoHistoryFileEnvKey  = "UNDO_HISTORY_FILE"
+	defaultUndoHistoryFile = "undo-history.json"
+)
+
+var undoHistory = courseapi.NewUndoHistory(courseapi.DefaultUndoLimit)
+
+// Where undo history is kept between runs: UNDO_HISTORY_FILE if set, otherwise next to the config
+// file. Setting UNDO_HISTORY_FILE to nothing keeps history for the session only.
+func undoHistoryPath() (string, bool) {
+	if path, exists := os.LookupEnv(undoHistoryFileEnvKey); exists {
+		return path, path != ""
+	}
+
+	return configFile(defaultUndoHistoryFile), true
+}
+
+// Reads the saved undo histories of every spreadsheet and tab, or none if there's no file yet
+func readUndoHistoryFile(path string) (courseapi.UndoHistoryFile, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return courseapi.NewUndoHistoryFile(), nil
+	} else if err != nil {
+		return courseapi.UndoHistoryFile{}, err
+	}
+	defer f.Close()
+
+	return courseapi.ReadUndoHistoryFile(f)
+}
+
+// Loads the undo history an earlier session saved for the current spreadsheet and tab. Without
+// one, history starts empty.
+func initUndoHistory() {
+	undoHistory = courseapi.NewUndoHistory(courseapi.DefaultUndoLimit)
+
+	path, persisted := undoHistoryPath()
+	if !persisted {
+		return
+	}
+
+	file, err := readUndoHistoryFile(path)
+	if err != nil {
+		log.Printf("Ignoring unreadable undo history `%s`: %v", path, err)
+		return
+	}
+
+	undoHistory = file.History(courseapi.SheetScope(spreadsheetId, sheetName), courseapi.DefaultUndoLimit)
+}
+
+// Saves the current spreadsheet and tab's undo history, keeping what's saved for the others
+func saveUndoHistory() {
+	path, persisted := undoHistoryPath()
+	if !persisted {
+		return
+	}
+
+	file, err := readUndoHistoryFile(path)
+	if err != nil {
+		log.Printf("Replacing unreadable undo history `%s`: %v", path, err)
+		file = courseapi.NewUndoHistoryFile()
+	}
+	file.Put(courseapi.SheetScope(spreadsheetId, sheetName), undoHistory)
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		log.Printf(UnsuccessfulUndoHistoryMsg+": %v", err)
+		return
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Printf(UnsuccessfulUndoHistoryMsg+": %v", err)
+		return
+	}
+	defer f.Close()
+
+	err = file.Write(f)
+	if err != nil {
+		log.Printf(UnsuccessfulUndoHistoryMsg+": %v", err)
+	}
+}
+
+// Records a successful command so it can be undone later
+func recordMutation(command string, changes ...courseapi.CourseSnapshot) {
+	undoHistory.Record(courseapi.NewMutation(command, changes...))
+	saveUndoHistory()
+}
+
+func courseCreated(course CourseItem) courseapi.CourseSnapshot {
+	return courseapi.CourseSnapshot{Name: course.Name, After: &course}
+}
+
+func courseUpdated(before CourseItem, after CourseItem) courseapi.CourseSnapshot {
+	return courseapi.CourseSnapshot{Name: after.Name, Before: &before, After: &after}
+}
+
+func courseRemoved(course CourseItem) courseapi.CourseSnapshot {
+	return courseapi.CourseSnapshot{Name: course.Name, Before: &course}
+}
+
+// Moves one course from its Before state to its After state, in both the store and the sheet.
+// Fails if the course no longer matches Before, e.g. because a refresh brought in remote edits.
+func applyCourseSnapshot(command string, change courseapi.CourseSnapshot) error {
+	switch {
+	case change.Before == nil && change.After != nil:
+		if courses.Exists(change.Name) {
+			return errors.New(UndoCourseExistsErrMsg)
+		}
+		return commitCourseCreation(command, *change.After)
+	case change.Before != nil && change.After == nil:
+		return commitCourseRemoval(command, *change.Before)
+	case change.Before != nil && change.After != nil:
+		return commitCourseUpdate(command, *change.Before, *change.After)
+	}
+
+	return nil
+}
+
+// Applies every change of the mutation, undoing the ones already applied if any of them fails
+func applyMutation(command string, m courseapi.Mutation) error {
+	for i, change := range m.Changes {
+		err := applyCourseSnapshot(command, change)
+		if err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if rollbackErr := applyCourseSnapshot(command, m.Changes[j].Inverse()); rollbackErr != nil {
+					log.Printf("Unable to roll back change to course `%s`: %v", m.Changes[j].Name, rollbackErr)
+				}
+			}
+			return fmt.Errorf("course `%s`: %v", change.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func undo() {
+	m, err := undoHistory.PeekUndo()
+	if err != nil {
+		fmt.Println("Nothing to undo")
+		return
+	}
+
+	err = applyMutation("undo "+m.Command, m.Inverse())
+	if err != nil {
+		log.Printf(UnsuccessfulUndoMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully undo `%s`: %v\n", m.Command, err)
+		return
+	}
+
+	undoHistory.Undo()
+	saveUndoHistory()
+
+	fmt.Printf("Undid `%s`\n", m.Command)
+}
+
+func redo() {
+	m, err := undoHistory.PeekRedo()
+	if err != nil {
+		fmt.Println("Nothing to redo")
+		return
+	}
+
+	err = applyMutation("redo "+m.Command, m)
+	if err != nil {
+		log.Printf(UnsuccessfulRedoMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully redo `%s`: %v\n", m.Command, err)
+		return
+	}
+
+	undoHistory.Redo()
+	saveUndoHistory()
+
+	fmt.Printf("Redid `%s`\n", m.Command)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	courseapi "go-sheets/courseapi"
+)
+
+const (
+	SetEffortCorrectUsageMsg = "Usage: set-effort <course_name> <assignment_number> <hours>|-"
+	WorkloadCorrectUsageMsg  = "Usage: workload [day|week] [<count>] [threshold=<hours>]"
+
+	UnsuccessfulEffortUpdateMsg = "Unable to successfully update estimated effort for reason"
+
+	workloadThresholdEnvKey = "WORKLOAD_THRESHOLD"
+	defaultWorkloadDays     = 14
+	defaultWorkloadWeeks    = 4
+)
+
+// Sets the estimated hours an assignment will take, or clears them with `-`
+func setEffort(command string, courseName string, numberArg string, hoursArg string) {
+	index, err := parseAssignmentNumber(numberArg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var edit func(*CourseItem) error
+	successMsg := fmt.Sprintf("Estimated effort cleared for assignment number `%s`", numberArg)
+	if hoursArg == "-" {
+		edit = func(c *CourseItem) error {
+			return c.ClearEffort(index)
+		}
+	} else {
+		hours, ok := parseNumberArg(strings.TrimSuffix(hoursArg, "h"))
+		if !ok {
+			return
+		}
+
+		successMsg = fmt.Sprintf("Assignment number `%s` is estimated to take %g hours", numberArg, hours)
+		edit = func(c *CourseItem) error {
+			return c.SetEffort(index, hours)
+		}
+	}
+
+	_, err = editCourse(command, courseName, edit)
+	if err != nil {
+		log.Printf(UnsuccessfulEffortUpdateMsg+": %v", err)
+
+		fmt.Printf("Unable to successfully update assignment number `%s`: %v\n", numberArg, err)
+		return
+	}
+
+	fmt.Println(successMsg)
+}
+
+// The daily hours above which workload flags a day: WORKLOAD_THRESHOLD if set, otherwise the default
+func workloadThreshold() float64 {
+	if value, exists := os.LookupEnv(workloadThresholdEnvKey); exists {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err == nil && !math.IsNaN(threshold) && !math.IsInf(threshold, 0) && threshold > 0 {
+			return threshold
+		}
+
+		log.Printf("Ignoring invalid %s `%s`", workloadThresholdEnvKey, value)
+	}
+
+	return courseapi.DefaultWorkloadThreshold
+}
+
+// Prints estimated effort per day or week from today across every course in the current term
+func workload(args []string) {
+	byWeek := true
+	count := 0
+	threshold := workloadThreshold()
+
+	for _, arg := range args {
+		if value, found := strings.CutPrefix(arg, "threshold="); found {
+			var ok bool
+			threshold, ok = parseNumberArg(strings.TrimSuffix(value, "h"))
+			if !ok {
+				return
+			}
+			continue
+		}
+
+		switch arg {
+		case "day":
+			byWeek = false
+		case "week":
+			byWeek = true
+		default:
+			n, err := strconv.Atoi(arg)
+			if err != nil || n <= 0 {
+				fmt.Println(WorkloadCorrectUsageMsg)
+				return
+			}
+			count = n
+		}
+	}
+
+	if count == 0 && byWeek {
+		count = defaultWorkloadWeeks
+	} else if count == 0 {
+		count = defaultWorkloadDays
+	}
+
+	if byWeek && count > courseapi.MaxWorkloadDays/7 || count > courseapi.MaxWorkloadDays {
+		fmt.Println(WorkloadCorrectUsageMsg)
+		fmt.Printf("At most %d days or %d weeks can be shown\n", courseapi.MaxWorkloadDays, courseapi.MaxWorkloadDays/7)
+		return
+	}
+
+	w, err := courseapi.NewWorkload(courses.Snapshot(), time.Now(), count, byWeek, threshold)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(w.String())
+}
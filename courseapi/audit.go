@@ -0,0 +1,130 @@
+package courseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	HistoryRowTooShortErrMsg = "history row is missing columns"
+	HistoryRowInvalidErrMsg  = "history row is malformed"
+
+	// Layout of timestamps shown by HistoryEntry.String
+	HistoryTimeFormat = "01/02/06 15:04"
+)
+
+// Column headers of the history tab, in order
+var HistoryHeader = []interface{}{"timestamp", "actor", "command", "course", "before", "after", "term"}
+
+// One append-only record of a change to a course. A nil Before means the course was
+// created, and a nil After means it was removed. Term is empty for entries recorded
+// before courses were organized into terms.
+type HistoryEntry struct {
+	At      time.Time
+	Actor   string
+	Command string
+	Course  string
+	Before  *CourseItem
+	After   *CourseItem
+	Term    string
+}
+
+func NewHistoryEntry(actor string, command string, before *CourseItem, after *CourseItem) HistoryEntry {
+	entry := HistoryEntry{At: time.Now(), Actor: actor, Command: command, Before: before, After: after}
+
+	if after != nil {
+		entry.Course = after.Name
+	} else if before != nil {
+		entry.Course = before.Name
+	}
+
+	return entry
+}
+
+func courseJSON(course *CourseItem) (string, error) {
+	if course == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(course)
+	return string(data), err
+}
+
+func parseCourseJSON(data string) (*CourseItem, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var course CourseItem
+	err := json.Unmarshal([]byte(data), &course)
+	return &course, err
+}
+
+// Converts the entry into a sheet row matching HistoryHeader
+func (e HistoryEntry) Row() ([]interface{}, error) {
+	before, err := courseJSON(e.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := courseJSON(e.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{e.At.Format(time.RFC3339), e.Actor, e.Command, e.Course, before, after, e.Term}, nil
+}
+
+// Parses a sheet row written by HistoryEntry.Row. Trailing empty cells may be omitted by sheets.
+func HistoryEntryFromRow(row []interface{}) (HistoryEntry, error) {
+	if len(row) < 4 {
+		return HistoryEntry{}, errors.New(HistoryRowTooShortErrMsg)
+	}
+
+	cells := make([]string, len(HistoryHeader))
+	for i := range cells {
+		if i < len(row) {
+			cells[i] = fmt.Sprint(row[i])
+		}
+	}
+
+	at, err := time.Parse(time.RFC3339, cells[0])
+	if err != nil {
+		return HistoryEntry{}, errors.New(HistoryRowInvalidErrMsg)
+	}
+
+	before, err := parseCourseJSON(cells[4])
+	if err != nil {
+		return HistoryEntry{}, errors.New(HistoryRowInvalidErrMsg)
+	}
+
+	after, err := parseCourseJSON(cells[5])
+	if err != nil {
+		return HistoryEntry{}, errors.New(HistoryRowInvalidErrMsg)
+	}
+
+	return HistoryEntry{At: at, Actor: cells[1], Command: cells[2], Course: cells[3], Before: before, After: after, Term: cells[6]}, nil
+}
+
+// Summarizes what the entry changed, reusing the CourseMapDiff format
+func (e HistoryEntry) Changes() CourseMapDiff {
+	before, after := CourseMap{}, CourseMap{}
+	if e.Before != nil {
+		before[e.Course] = e.Before
+	}
+	if e.After != nil {
+		after[e.Course] = e.After
+	}
+
+	return DiffCourseMaps(before, after)
+}
+
+func (e HistoryEntry) String() string {
+	header := fmt.Sprintf("%s  %s  %s", e.At.Local().Format(HistoryTimeFormat), e.Actor, e.Command)
+
+	changes := e.Changes().String()
+	return fmt.Sprintf("%s\n    %s", header, strings.ReplaceAll(changes, "\n", "\n    "))
+}
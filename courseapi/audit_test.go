@@ -0,0 +1,76 @@
+package courseapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHistoryEntry_CourseNameFromSnapshot_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+
+	created := NewHistoryEntry("alice", "create-course CS101", nil, &course)
+	removed := NewHistoryEntry("alice", "remove-course CS101", &course, nil)
+
+	assert.Equal(t, "CS101", created.Course)
+	assert.Equal(t, "CS101", removed.Course)
+}
+
+func TestHistoryEntry_RowRoundTrip_Success(t *testing.T) {
+	before := CourseItem{Name: "CS101", Assignments: AssignmentList{}}
+	after := before.DeepCopy()
+	after.Assignments.AddAssignment("Lab 1", "02/02/25")
+
+	entry := HistoryEntry{
+		At:      time.Date(2025, 2, 1, 9, 30, 0, 0, time.UTC),
+		Actor:   "alice",
+		Command: "create-assignment CS101 Lab 1",
+		Course:  "CS101",
+		Before:  &before,
+		After:   &after,
+		Term:    "Spring 2025",
+	}
+
+	row, err := entry.Row()
+	assert.NoError(t, err)
+	assert.Equal(t, len(HistoryHeader), len(row))
+	assert.Equal(t, "2025-02-01T09:30:00Z", row[0])
+
+	parsed, err := HistoryEntryFromRow(row)
+	assert.NoError(t, err)
+	assert.True(t, entry.At.Equal(parsed.At))
+	assert.Equal(t, "alice", parsed.Actor)
+	assert.Equal(t, "Spring 2025", parsed.Term)
+	assert.Equal(t, "Lab 1", parsed.After.Assignments[0].Name)
+	assert.Equal(t, 0, len(parsed.Before.Assignments))
+}
+
+func TestHistoryEntryFromRow_TrailingCellsOmitted_Success(t *testing.T) {
+	row := []interface{}{"2025-02-01T09:30:00Z", "alice", "remove-course CS101", "CS101", `{"name":"CS101","assignments":[]}`}
+
+	parsed, err := HistoryEntryFromRow(row)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CS101", parsed.Before.Name)
+	assert.Nil(t, parsed.After)
+	assert.Equal(t, "", parsed.Term)
+}
+
+func TestHistoryEntryFromRow_Malformed_Failure(t *testing.T) {
+	_, err := HistoryEntryFromRow([]interface{}{"2025-02-01T09:30:00Z", "alice"})
+	assert.EqualError(t, err, HistoryRowTooShortErrMsg)
+
+	_, err = HistoryEntryFromRow([]interface{}{"yesterday", "alice", "undo", "CS101"})
+	assert.EqualError(t, err, HistoryRowInvalidErrMsg)
+
+	_, err = HistoryEntryFromRow([]interface{}{"2025-02-01T09:30:00Z", "alice", "undo", "CS101", "{not json"})
+	assert.EqualError(t, err, HistoryRowInvalidErrMsg)
+}
+
+func TestHistoryEntry_Changes_Removal_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+	entry := NewHistoryEntry("alice", "remove-course CS101", &course, nil)
+
+	assert.Equal(t, "- Course `CS101` removed", entry.Changes().String())
+}
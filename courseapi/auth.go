@@ -0,0 +1,290 @@
+package courseapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	InvalidAuthErrMsg        = "auth must be service-account or oauth"
+	NotLoggedInErrMsg        = "not logged in, run `login` first"
+	LoginStateMismatchErrMsg = "login response doesn't match the request, please try again"
+	LoginDeniedErrMsg        = "login was denied"
+	MissingLoginCodeErrMsg   = "login response has no authorization code"
+
+	// Ways of authenticating with Google: a service account key, or a user's own account via an
+	// OAuth client for desktop apps
+	ServiceAccountAuth = "service-account"
+	OAuthAuth          = "oauth"
+
+	GoogleRevokeURL = "https://oauth2.googleapis.com/revoke"
+
+	loginSuccessPage = `<!DOCTYPE html>
+<html><body style="font-family: sans-serif"><p>go-sheets is logged in. You can close this tab.</p></body></html>
+`
+)
+
+func ValidateAuth(auth string) error {
+	if auth != ServiceAccountAuth && auth != OAuthAuth {
+		return fmt.Errorf("%s, not `%s`", InvalidAuthErrMsg, auth)
+	}
+
+	return nil
+}
+
+// Reads an OAuth client downloaded from the Google Cloud console (an "installed" or "web" client)
+func LoadOAuthClient(path string, scopes ...string) (*oauth2.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := google.ConfigFromJSON(data, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("`%s` isn't an OAuth client: %v", path, err)
+	}
+
+	return config, nil
+}
+
+// A file holding the OAuth token for one login. It's only readable by its owner, since the
+// refresh token grants access to the account.
+type TokenCache struct {
+	Path string
+}
+
+func (c TokenCache) Load() (*oauth2.Token, error) {
+	data, err := os.ReadFile(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New(NotLoggedInErrMsg)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var token oauth2.Token
+	err = json.Unmarshal(data, &token)
+	if err != nil {
+		return nil, fmt.Errorf("`%s` isn't a valid token: %v", c.Path, err)
+	}
+
+	return &token, nil
+}
+
+func (c TokenCache) Save(token *oauth2.Token) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(c.Path), 0700)
+	if err != nil {
+		return err
+	}
+
+	// Written then renamed, so an interrupted save can't lose the refresh token
+	tmp := c.Path + ".tmp"
+	err = os.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.Path)
+}
+
+// Removes the cached token. There being no token to remove isn't an error.
+func (c TokenCache) Delete() error {
+	err := os.Remove(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// A token source backed by a TokenCache: the token is read from the cache when first needed,
+// refreshed when it expires and written back, so the login carries over between runs.
+type CachedTokenSource struct {
+	Config *oauth2.Config
+	Cache  TokenCache
+
+	mu  sync.Mutex
+	src oauth2.TokenSource
+	// The access token last read from or written to the cache
+	cached string
+}
+
+func (s *CachedTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.src == nil {
+		token, err := s.Cache.Load()
+		if err != nil {
+			return nil, err
+		}
+
+		s.src = s.Config.TokenSource(context.Background(), token)
+		s.cached = token.AccessToken
+	}
+
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token.AccessToken != s.cached {
+		err = s.Cache.Save(token)
+		if err != nil {
+			return nil, err
+		}
+		s.cached = token.AccessToken
+	}
+
+	return token, nil
+}
+
+// Forgets the current token, so the next call reads the cache again, e.g. after logging in or out
+func (s *CachedTokenSource) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.src = nil
+	s.cached = ""
+}
+
+// Logs in with the flow for installed apps: a one-off server on a loopback port receives the
+// redirect from the consent page, which open is asked to show in a browser, and the code it
+// carries is exchanged for a token. The request is tied to the response with a random state and
+// a PKCE verifier. Waits until the redirect arrives or ctx is done.
+func LoopbackLogin(ctx context.Context, config oauth2.Config, open func(authURL string) error) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+
+	config.RedirectURL = "http://" + listener.Addr().String() + "/"
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	results := make(chan loginResponse, 1)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		res := loginResult(r.URL.Query(), state)
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, loginSuccessPage)
+		}
+
+		select {
+		case results <- res:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, oauth2.S256ChallengeOption(verifier))
+	err = open(authURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var res loginResponse
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return config.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+}
+
+// What the consent page redirected back with: an authorization code, or why there isn't one
+type loginResponse struct {
+	code string
+	err  error
+}
+
+func loginResult(query url.Values, state string) loginResponse {
+	if query.Get("state") != state {
+		return loginResponse{err: errors.New(LoginStateMismatchErrMsg)}
+	}
+
+	if reason := query.Get("error"); reason != "" {
+		return loginResponse{err: fmt.Errorf("%s: %s", LoginDeniedErrMsg, reason)}
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return loginResponse{err: errors.New(MissingLoginCodeErrMsg)}
+	}
+
+	return loginResponse{code: code}
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Revokes token at revokeURL (GoogleRevokeURL for Google), so it can't be used even if a copy of it
+// survives. Revoking the refresh token also revokes the access tokens issued from it.
+func RevokeToken(ctx context.Context, revokeURL string, token *oauth2.Token) error {
+	value := token.RefreshToken
+	if value == "" {
+		value = token.AccessToken
+	}
+
+	form := url.Values{"token": {value}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to revoke token: %s", resp.Status)
+	}
+
+	return nil
+}
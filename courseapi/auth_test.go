@@ -0,0 +1,208 @@
+package courseapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+// A token endpoint that issues numbered access tokens, checking each request with check
+func newTestTokenServer(t *testing.T, check func(form url.Values)) (*httptest.Server, *int32) {
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if check != nil {
+			check(r.PostForm)
+		}
+
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access-" + string(rune('0'+n)),
+			"refresh_token": "refresh",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+func newTestOAuthConfig(tokenURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     oauth2.Endpoint{AuthURL: "https://accounts.example.com/auth", TokenURL: tokenURL},
+		Scopes:       []string{"https://www.googleapis.com/auth/spreadsheets"},
+	}
+}
+
+func TestLoadOAuthClient_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oauth-client.json")
+	err := os.WriteFile(path, []byte(`{"installed": {
+		"client_id": "client", "client_secret": "secret",
+		"auth_uri": "https://accounts.google.com/o/oauth2/auth", "token_uri": "https://oauth2.googleapis.com/token",
+		"redirect_uris": ["http://localhost"]
+	}}`), 0600)
+	assert.NoError(t, err)
+
+	config, err := LoadOAuthClient(path, "scope")
+	assert.NoError(t, err)
+	assert.Equal(t, "client", config.ClientID)
+	assert.Equal(t, []string{"scope"}, config.Scopes)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"type": "service_account"}`), 0600))
+	_, err = LoadOAuthClient(path)
+	assert.Error(t, err)
+}
+
+func TestTokenCache_Success(t *testing.T) {
+	cache := TokenCache{Path: filepath.Join(t.TempDir(), "profiles", "token.json")}
+
+	_, err := cache.Load()
+	assert.EqualError(t, err, NotLoggedInErrMsg)
+
+	token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(t, cache.Save(token))
+
+	info, err := os.Stat(cache.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := cache.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh", loaded.RefreshToken)
+	assert.True(t, token.Expiry.Equal(loaded.Expiry))
+
+	assert.NoError(t, cache.Delete())
+	assert.NoError(t, cache.Delete())
+	_, err = cache.Load()
+	assert.EqualError(t, err, NotLoggedInErrMsg)
+}
+
+func TestCachedTokenSource_Refresh_Success(t *testing.T) {
+	server, issued := newTestTokenServer(t, func(form url.Values) {
+		assert.Equal(t, "refresh_token", form.Get("grant_type"))
+		assert.Equal(t, "refresh", form.Get("refresh_token"))
+	})
+
+	cache := TokenCache{Path: filepath.Join(t.TempDir(), "token.json")}
+	expired := &oauth2.Token{AccessToken: "stale", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}
+	assert.NoError(t, cache.Save(expired))
+
+	src := &CachedTokenSource{Config: newTestOAuthConfig(server.URL), Cache: cache}
+	token, err := src.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+
+	// The refreshed token is saved, and reused until it expires
+	cached, err := cache.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "access-1", cached.AccessToken)
+	assert.Equal(t, "refresh", cached.RefreshToken)
+
+	token, err = src.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, int32(1), atomic.LoadInt32(issued))
+
+	assert.NoError(t, cache.Delete())
+	src.Reset()
+	_, err = src.Token()
+	assert.EqualError(t, err, NotLoggedInErrMsg)
+}
+
+// Stands in for the browser: follows the consent page straight back to the redirect URL with params
+func redirectBack(t *testing.T, params func(auth url.Values) url.Values) func(string) error {
+	return func(authURL string) error {
+		parsed, err := url.Parse(authURL)
+		assert.NoError(t, err)
+		auth := parsed.Query()
+
+		redirect, err := url.Parse(auth.Get("redirect_uri"))
+		assert.NoError(t, err)
+		redirect.RawQuery = params(auth).Encode()
+
+		resp, err := http.Get(redirect.String())
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+}
+
+func TestLoopbackLogin_Success(t *testing.T) {
+	server, _ := newTestTokenServer(t, func(form url.Values) {
+		assert.Equal(t, "authorization_code", form.Get("grant_type"))
+		assert.Equal(t, "code", form.Get("code"))
+		assert.NotEmpty(t, form.Get("code_verifier"))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := LoopbackLogin(ctx, *newTestOAuthConfig(server.URL), redirectBack(t, func(auth url.Values) url.Values {
+		assert.Equal(t, "offline", auth.Get("access_type"))
+		assert.Equal(t, "S256", auth.Get("code_challenge_method"))
+		assert.Regexp(t, `^http://127\.0\.0\.1:\d+/$`, auth.Get("redirect_uri"))
+
+		return url.Values{"code": {"code"}, "state": {auth.Get("state")}}
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+}
+
+func TestLoopbackLogin_Failure(t *testing.T) {
+	server, issued := newTestTokenServer(t, nil)
+	config := *newTestOAuthConfig(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := LoopbackLogin(ctx, config, redirectBack(t, func(auth url.Values) url.Values {
+		return url.Values{"code": {"code"}, "state": {"forged"}}
+	}))
+	assert.EqualError(t, err, LoginStateMismatchErrMsg)
+
+	_, err = LoopbackLogin(ctx, config, redirectBack(t, func(auth url.Values) url.Values {
+		return url.Values{"error": {"access_denied"}, "state": {auth.Get("state")}}
+	}))
+	assert.EqualError(t, err, LoginDeniedErrMsg+": access_denied")
+	assert.Equal(t, int32(0), atomic.LoadInt32(issued))
+
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	_, err = LoopbackLogin(short, config, func(string) error { return nil })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRevokeToken_Success(t *testing.T) {
+	var revoked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		revoked = r.PostForm.Get("token")
+		if revoked == "unknown" {
+			http.Error(w, "invalid_token", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	err := RevokeToken(context.Background(), server.URL, &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"})
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh", revoked)
+
+	err = RevokeToken(context.Background(), server.URL, &oauth2.Token{AccessToken: "unknown"})
+	assert.Error(t, err)
+}
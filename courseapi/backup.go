@@ -0,0 +1,79 @@
+package courseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	InvalidBackupErrMsg = "backup is missing its courses"
+
+	// Layout used in backup names, e.g. `backup-20250201-093000`
+	BackupNameFormat = "20060102-150405"
+)
+
+// A point-in-time copy of every course in a term
+type Backup struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Term      string    `json:"term,omitempty"`
+	Courses   CourseMap `json:"courses"`
+}
+
+// Deep-copies cm into a backup named after the time it was taken
+func NewBackup(cm CourseMap, at time.Time) Backup {
+	courses := make(CourseMap, len(cm))
+	for name, course := range cm {
+		cpy := course.DeepCopy()
+		courses[name] = &cpy
+	}
+
+	return Backup{Name: "backup-" + at.Format(BackupNameFormat), CreatedAt: at, Courses: courses}
+}
+
+func (b Backup) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(b)
+}
+
+func ReadBackup(r io.Reader) (Backup, error) {
+	var b Backup
+	err := json.NewDecoder(r).Decode(&b)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	if b.Courses == nil {
+		return Backup{}, errors.New(InvalidBackupErrMsg)
+	}
+
+	return b, nil
+}
+
+// The snapshots that turn current into the backup's contents, one per course that differs
+func (b Backup) RestoreChanges(current CourseMap) []CourseSnapshot {
+	var changes []CourseSnapshot
+	diff := DiffCourseMaps(current, b.Courses)
+
+	for _, name := range diff.AddedCourses {
+		after := b.Courses[name].DeepCopy()
+		changes = append(changes, CourseSnapshot{Name: name, After: &after})
+	}
+
+	for _, name := range diff.RemovedCourses {
+		before := current[name].DeepCopy()
+		changes = append(changes, CourseSnapshot{Name: name, Before: &before})
+	}
+
+	for _, courseDiff := range diff.ChangedCourses {
+		before := current[courseDiff.Name].DeepCopy()
+		after := b.Courses[courseDiff.Name].DeepCopy()
+		changes = append(changes, CourseSnapshot{Name: courseDiff.Name, Before: &before, After: &after})
+	}
+
+	return changes
+}
@@ -0,0 +1,70 @@
+package courseapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackup_CopiesCourses_Success(t *testing.T) {
+	cm := CourseMap{"CS101": {Name: "CS101", Assignments: AssignmentList{}}}
+	at := time.Date(2025, 2, 1, 9, 30, 0, 0, time.UTC)
+
+	b := NewBackup(cm, at)
+	cm["CS101"].Assignments.AddAssignment("Lab 1", "02/02/25")
+
+	assert.Equal(t, "backup-20250201-093000", b.Name)
+	assert.Equal(t, 0, len(b.Courses["CS101"].Assignments))
+}
+
+func TestBackup_WriteRead_RoundTrip_Success(t *testing.T) {
+	info := "Intro to CS"
+	cm := CourseMap{"CS101": {Name: "CS101", Course_Info: &info, Assignments: AssignmentList{}}}
+	cm["CS101"].Assignments.AddAssignment("Lab 1", "02/02/25")
+	b := NewBackup(cm, time.Date(2025, 2, 1, 9, 30, 0, 0, time.UTC))
+
+	var buf bytes.Buffer
+	assert.NoError(t, b.Write(&buf))
+
+	read, err := ReadBackup(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, b.Name, read.Name)
+	assert.True(t, b.CreatedAt.Equal(read.CreatedAt))
+	assert.Equal(t, "Intro to CS", *read.Courses["CS101"].Course_Info)
+	assert.Equal(t, "Lab 1", read.Courses["CS101"].Assignments[0].Name)
+}
+
+func TestReadBackup_MissingCourses_Failure(t *testing.T) {
+	_, err := ReadBackup(strings.NewReader(`{"name": "backup-20250201-093000"}`))
+	assert.EqualError(t, err, InvalidBackupErrMsg)
+
+	_, err = ReadBackup(strings.NewReader(`not json`))
+	assert.Error(t, err)
+}
+
+func TestBackup_RestoreChanges_Success(t *testing.T) {
+	b := NewBackup(CourseMap{
+		"CS101":   newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"}),
+		"MATH100": newTestCourse("MATH100"),
+		"ART100":  newTestCourse("ART100"),
+	}, time.Now())
+	current := CourseMap{
+		"CS101":  newTestCourse("CS101"),
+		"ART100": newTestCourse("ART100"),
+		"BIO110": newTestCourse("BIO110"),
+	}
+
+	changes := b.RestoreChanges(current)
+
+	assert.Equal(t, 3, len(changes))
+	assert.Equal(t, "MATH100", changes[0].Name)
+	assert.Nil(t, changes[0].Before)
+	assert.Equal(t, "BIO110", changes[1].Name)
+	assert.Nil(t, changes[1].After)
+	assert.Equal(t, "CS101", changes[2].Name)
+	assert.Equal(t, 0, len(changes[2].Before.Assignments))
+	assert.Equal(t, 1, len(changes[2].After.Assignments))
+}
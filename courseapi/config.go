@@ -0,0 +1,271 @@
+package courseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	UnknownProfileErrMsg     = "profile isn't defined in the config file"
+	InvalidProfileNameErrMsg = "profile names may only contain letters, digits, - and _"
+	InvalidDatePatternErrMsg = "date format must contain a day (DD), month (MM) and year (YY or YYYY), e.g. MM/DD/YY or YYYY-MM-DD"
+	InvalidTimeZoneErrMsg    = "time zone must be an IANA name such as America/New_York, or Local"
+	InvalidSMTPPortErrMsg    = "SMTP port must be a number greater than 0"
+
+	// Directory under the user's config directory ($XDG_CONFIG_HOME, usually ~/.config) holding go-sheets' files
+	ConfigDirName  = "go-sheets"
+	ConfigFileName = "config.json"
+)
+
+// Everything that decides which spreadsheet go-sheets uses and how. Empty fields are unset,
+// so one Settings can be layered over another with Merge.
+type Settings struct {
+	// ServiceAccountAuth or OAuthAuth, which decides whether Credentials is a service account key or an OAuth client
+	Auth          string `json:"auth,omitempty"`
+	Credentials   string `json:"credentials,omitempty"`
+	TokenFile     string `json:"token_file,omitempty"`
+	SpreadsheetID string `json:"spreadsheet_id,omitempty"`
+	Tab           string `json:"tab,omitempty"`
+	TimeZone      string `json:"time_zone,omitempty"`
+	DateFormat    string `json:"date_format,omitempty"`
+	LogFile       string `json:"log_file,omitempty"`
+	EnvFile       string `json:"env_file,omitempty"`
+	// Addresses a newly created spreadsheet is shared with, in the form ParseGrants reads
+	ShareWith string `json:"share_with,omitempty"`
+
+	// Where the digest is mailed from and to; DigestTo is a comma-separated list of addresses
+	SMTPHost     string `json:"smtp_host,omitempty"`
+	SMTPPort     string `json:"smtp_port,omitempty"`
+	SMTPUsername string `json:"smtp_username,omitempty"`
+	SMTPPassword string `json:"smtp_password,omitempty"`
+	DigestFrom   string `json:"digest_from,omitempty"`
+	DigestTo     string `json:"digest_to,omitempty"`
+}
+
+// Returns s with every field that is set in override replaced
+func (s Settings) Merge(override Settings) Settings {
+	for _, field := range []struct{ dst, src *string }{
+		{&s.Auth, &override.Auth},
+		{&s.Credentials, &override.Credentials},
+		{&s.TokenFile, &override.TokenFile},
+		{&s.SpreadsheetID, &override.SpreadsheetID},
+		{&s.Tab, &override.Tab},
+		{&s.TimeZone, &override.TimeZone},
+		{&s.DateFormat, &override.DateFormat},
+		{&s.LogFile, &override.LogFile},
+		{&s.EnvFile, &override.EnvFile},
+		{&s.ShareWith, &override.ShareWith},
+		{&s.SMTPHost, &override.SMTPHost},
+		{&s.SMTPPort, &override.SMTPPort},
+		{&s.SMTPUsername, &override.SMTPUsername},
+		{&s.SMTPPassword, &override.SMTPPassword},
+		{&s.DigestFrom, &override.DigestFrom},
+		{&s.DigestTo, &override.DigestTo},
+	} {
+		if *field.src != "" {
+			*field.dst = *field.src
+		}
+	}
+
+	return s
+}
+
+// Resolves relative file paths against dir, e.g. the directory of the config file they came from
+func (s Settings) RelativeTo(dir string) Settings {
+	for _, path := range []*string{&s.Credentials, &s.TokenFile, &s.LogFile, &s.EnvFile} {
+		if *path != "" && !filepath.IsAbs(*path) {
+			*path = filepath.Join(dir, *path)
+		}
+	}
+
+	return s
+}
+
+func (s Settings) Validate() error {
+	if s.Auth != "" {
+		if err := ValidateAuth(s.Auth); err != nil {
+			return err
+		}
+	}
+
+	if s.DateFormat != "" {
+		if _, err := ParseDatePattern(s.DateFormat); err != nil {
+			return err
+		}
+	}
+
+	if s.TimeZone != "" {
+		if _, err := LoadTimeZone(s.TimeZone); err != nil {
+			return err
+		}
+	}
+
+	if _, err := ParseGrants(s.ShareWith); err != nil {
+		return err
+	}
+
+	if _, err := s.SMTP(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// The mail settings, with the port defaulting to DefaultSMTPPort
+func (s Settings) SMTP() (SMTPConfig, error) {
+	config := SMTPConfig{
+		Host:     s.SMTPHost,
+		Port:     DefaultSMTPPort,
+		Username: s.SMTPUsername,
+		Password: s.SMTPPassword,
+		From:     s.DigestFrom,
+	}
+
+	if s.SMTPPort != "" {
+		port, err := strconv.Atoi(s.SMTPPort)
+		if err != nil || port <= 0 {
+			return SMTPConfig{}, errors.New(InvalidSMTPPortErrMsg)
+		}
+		config.Port = port
+	}
+
+	for _, address := range strings.Split(s.DigestTo, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			config.To = append(config.To, address)
+		}
+	}
+
+	return config, nil
+}
+
+// The contents of the config file: settings shared by every profile, and named profiles that override them
+type Config struct {
+	Settings
+	DefaultProfile string              `json:"default_profile,omitempty"`
+	Profiles       map[string]Settings `json:"profiles,omitempty"`
+}
+
+// Where the config file lives by default: $XDG_CONFIG_HOME/go-sheets/config.json, or the platform's equivalent
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, ConfigDirName, ConfigFileName), nil
+}
+
+// Reads the config file at path. A missing file is an empty config, since the file is optional.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	} else if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return Config{}, fmt.Errorf("`%s` isn't valid JSON: %v", path, err)
+	}
+
+	for name, profile := range c.Profiles {
+		if err := ValidateProfileName(name); err != nil {
+			return Config{}, fmt.Errorf("profile `%s`: %v", name, err)
+		}
+		if err := profile.Validate(); err != nil {
+			return Config{}, fmt.Errorf("profile `%s`: %v", name, err)
+		}
+	}
+
+	return c, c.Settings.Validate()
+}
+
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return errors.New(InvalidProfileNameErrMsg)
+	}
+
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return errors.New(InvalidProfileNameErrMsg)
+		}
+	}
+
+	return nil
+}
+
+// The settings for a profile, layered over the shared ones. An empty name picks the default
+// profile, if there is one. Returns the name of the profile that was used.
+func (c Config) Resolve(profile string) (Settings, string, error) {
+	if profile == "" {
+		profile = c.DefaultProfile
+	}
+
+	if profile == "" {
+		return c.Settings, "", nil
+	}
+
+	override, exists := c.Profiles[profile]
+	if !exists {
+		return Settings{}, "", fmt.Errorf("`%s`: %s", profile, UnknownProfileErrMsg)
+	}
+
+	return c.Settings.Merge(override), profile, nil
+}
+
+// Profile names in sorted order
+func (c Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Converts a date pattern such as MM/DD/YY or YYYY-MM-DD into a Go time layout
+func ParseDatePattern(pattern string) (string, error) {
+	layout := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(strings.ToUpper(pattern))
+
+	// Every part of the date must be present, and nothing else may look like a layout element
+	if strings.Count(layout, "01") != 1 || strings.Count(layout, "02") != 1 || strings.Count(layout, "06") != 1 {
+		return "", errors.New(InvalidDatePatternErrMsg)
+	}
+
+	for _, r := range strings.NewReplacer("2006", "", "06", "", "01", "", "02", "").Replace(layout) {
+		if r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' {
+			return "", errors.New(InvalidDatePatternErrMsg)
+		}
+	}
+
+	date := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	if parsed, err := time.Parse(layout, date.Format(layout)); err != nil || !parsed.Equal(date) {
+		return "", errors.New(InvalidDatePatternErrMsg)
+	}
+
+	return layout, nil
+}
+
+// Loads an IANA time zone, where Local is the system's own
+func LoadTimeZone(name string) (*time.Location, error) {
+	if strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: `%s`", InvalidTimeZoneErrMsg, name)
+	}
+
+	return loc, nil
+}
@@ -0,0 +1,123 @@
+package courseapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_Resolve_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	err := os.WriteFile(path, []byte(`{
+		"credentials": "service-account.json",
+		"time_zone": "America/New_York",
+		"default_profile": "personal",
+		"profiles": {
+			"personal": {"spreadsheet_id": "abc", "tab": "Fall 2026", "auth": "oauth"},
+			"ta": {"spreadsheet_id": "xyz", "credentials": "/secrets/ta.json", "date_format": "YYYY-MM-DD"}
+		}
+	}`), 0644)
+	assert.NoError(t, err)
+
+	c, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"personal", "ta"}, c.ProfileNames())
+
+	settings, profile, err := c.Resolve("")
+	assert.NoError(t, err)
+	assert.Equal(t, "personal", profile)
+	assert.Equal(t, Settings{Auth: OAuthAuth, Credentials: "service-account.json", SpreadsheetID: "abc", Tab: "Fall 2026", TimeZone: "America/New_York"}, settings)
+
+	settings, profile, err = c.Resolve("ta")
+	assert.NoError(t, err)
+	assert.Equal(t, "ta", profile)
+	assert.Equal(t, "/secrets/ta.json", settings.Credentials)
+	assert.Equal(t, "America/New_York", settings.TimeZone)
+
+	settings = settings.RelativeTo("/home/me/.config/go-sheets").Merge(Settings{LogFile: "run.log"})
+	assert.Equal(t, "/secrets/ta.json", settings.Credentials)
+	assert.Equal(t, "run.log", settings.LogFile)
+	assert.Equal(t, filepath.Join("/home/me/.config/go-sheets", "x.env"), Settings{EnvFile: "x.env"}.RelativeTo("/home/me/.config/go-sheets").EnvFile)
+
+	_, _, err = c.Resolve("work")
+	assert.EqualError(t, err, "`work`: "+UnknownProfileErrMsg)
+}
+
+func TestLoadConfig_Missing_Success(t *testing.T) {
+	c, err := LoadConfig(filepath.Join(t.TempDir(), ConfigFileName))
+	assert.NoError(t, err)
+
+	settings, profile, err := c.Resolve("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", profile)
+	assert.Equal(t, Settings{}, settings)
+}
+
+func TestLoadConfig_Failure(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"syntax.json":  `{"profiles": `,
+		"date.json":    `{"date_format": "MM/YY"}`,
+		"zone.json":    `{"profiles": {"x": {"time_zone": "Mars/Olympus_Mons"}}}`,
+		"profile.json": `{"profiles": {"my profile": {}}}`,
+		"auth.json":    `{"profiles": {"x": {"auth": "password"}}}`,
+		"share.json":   `{"share_with": "alice@example.com:owner"}`,
+		"smtp.json":    `{"profiles": {"x": {"smtp_port": "submission"}}}`,
+	} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		_, err := LoadConfig(path)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestSettings_SMTP_Success(t *testing.T) {
+	config, err := Settings{}.SMTP()
+	assert.NoError(t, err)
+	assert.Equal(t, SMTPConfig{Port: DefaultSMTPPort}, config)
+
+	shared := Settings{SMTPHost: "smtp.example.com", DigestFrom: "group@example.com", DigestTo: "alice@example.com, bob@example.com,"}
+	config, err = shared.Merge(Settings{SMTPPort: "465", SMTPUsername: "group", SMTPPassword: "secret"}).SMTP()
+	assert.NoError(t, err)
+	assert.Equal(t, SMTPConfig{
+		Host: "smtp.example.com", Port: 465, Username: "group", Password: "secret",
+		From: "group@example.com", To: []string{"alice@example.com", "bob@example.com"},
+	}, config)
+
+	_, err = Settings{SMTPPort: "0"}.SMTP()
+	assert.EqualError(t, err, InvalidSMTPPortErrMsg)
+}
+
+func TestParseDatePattern_Success(t *testing.T) {
+	for pattern, layout := range map[string]string{
+		"MM/DD/YY":   "01/02/06",
+		"yyyy-mm-dd": "2006-01-02",
+		"DD.MM.YYYY": "02.01.2006",
+	} {
+		parsed, err := ParseDatePattern(pattern)
+		assert.NoError(t, err)
+		assert.Equal(t, layout, parsed)
+	}
+
+	for _, pattern := range []string{"", "MM/DD", "MM/DD/YY/YY", "MMM DD YY", "MM/DD/YY 15:04"} {
+		_, err := ParseDatePattern(pattern)
+		assert.EqualError(t, err, InvalidDatePatternErrMsg, pattern)
+	}
+}
+
+func TestLoadTimeZone_Success(t *testing.T) {
+	loc, err := LoadTimeZone("local")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Local, loc)
+
+	loc, err = LoadTimeZone("Europe/Berlin")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+
+	_, err = LoadTimeZone("Nowhere")
+	assert.Error(t, err)
+}
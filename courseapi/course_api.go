@@ -9,13 +9,25 @@ import (
 )
 
 const (
-	// Format for inputted date strings is `MM/DD/YY`
-	DateFormat               = "01/02/06"
-	InvalidDateErrMsg        = "invalid date passed in (please use mm/dd/yy)"
+	// Format for inputted date strings is `MM/DD/YY`, unless configured otherwise
+	DefaultDateFormat        = "01/02/06"
+	InvalidDateErrMsg        = "invalid date passed in"
 	TooManyParamsErrMsg      = "excess info strings passed to addAssignment"
 	InvalidSliceRemoveErrMsg = "tried to remove an out-of-bounds slice index"
 )
 
+// Layout used to show and parse dates, set once at startup from the configured date format
+var DateFormat = DefaultDateFormat
+
+// DateFormat as users write it, e.g. MM/DD/YY, for messages about how to enter dates
+func DatePattern() string {
+	return strings.NewReplacer("2006", "YYYY", "06", "YY", "01", "MM", "02", "DD").Replace(DateFormat)
+}
+
+func invalidDateError() error {
+	return fmt.Errorf("%s (please use %s)", InvalidDateErrMsg, DatePattern())
+}
+
 type CourseMap map[string]*CourseItem
 
 func (cm CourseMap) String() string {
@@ -32,9 +44,12 @@ func (cm CourseMap) String() string {
 }
 
 type CourseItem struct {
-	Name        string         `json:"name"`
-	Course_Info *string        `json:"course_info,omitempty"`
-	Assignments AssignmentList `json:"assignments"`
+	Name         string          `json:"name"`
+	Course_Info  *string         `json:"course_info,omitempty"`
+	Assignments  AssignmentList  `json:"assignments"`
+	Categories   []GradeCategory `json:"categories,omitempty"`
+	GradeCutoffs []LetterCutoff  `json:"grade_cutoffs,omitempty"`
+	Details      *CourseDetails  `json:"details,omitempty"`
 }
 
 func (c CourseItem) DeepCopy() CourseItem {
@@ -48,7 +63,24 @@ func (c CourseItem) DeepCopy() CourseItem {
 		cpy.Course_Info = &infoCopy
 	}
 
-	copy(cpy.Assignments, c.Assignments)
+	for i, assignment := range c.Assignments {
+		cpy.Assignments[i] = assignment.DeepCopy()
+	}
+
+	if c.Categories != nil {
+		cpy.Categories = make([]GradeCategory, len(c.Categories))
+		copy(cpy.Categories, c.Categories)
+	}
+
+	if c.GradeCutoffs != nil {
+		cpy.GradeCutoffs = make([]LetterCutoff, len(c.GradeCutoffs))
+		copy(cpy.GradeCutoffs, c.GradeCutoffs)
+	}
+
+	if c.Details != nil {
+		detailsCopy := c.Details.DeepCopy()
+		cpy.Details = &detailsCopy
+	}
 
 	return cpy
 }
@@ -67,9 +99,70 @@ func (c CourseItem) DetailedString() string {
 }
 
 type AssignmentItem struct {
-	Name  string    `json:"name"`
-	Info  *string   `json:"info,omitempty"`
-	DueAt time.Time `json:"due_at"`
+	Name           string          `json:"name"`
+	Info           *string         `json:"info,omitempty"`
+	DueAt          time.Time       `json:"due_at"`
+	Category       *string         `json:"category,omitempty"`
+	PointsPossible *float64        `json:"points_possible,omitempty"`
+	PointsEarned   *float64        `json:"points_earned,omitempty"`
+	Tags           []string        `json:"tags,omitempty"`
+	EstimatedHours *float64        `json:"estimated_hours,omitempty"`
+	Subtasks       []Subtask       `json:"subtasks,omitempty"`
+	Prerequisites  []AssignmentRef `json:"prerequisites,omitempty"`
+	Reminders      []string        `json:"reminders,omitempty"`
+}
+
+func (a AssignmentItem) DeepCopy() AssignmentItem {
+	cpy := a
+
+	if a.Info != nil {
+		infoCopy := *a.Info
+		cpy.Info = &infoCopy
+	}
+
+	if a.Category != nil {
+		categoryCopy := *a.Category
+		cpy.Category = &categoryCopy
+	}
+
+	if a.PointsPossible != nil {
+		possibleCopy := *a.PointsPossible
+		cpy.PointsPossible = &possibleCopy
+	}
+
+	if a.PointsEarned != nil {
+		earnedCopy := *a.PointsEarned
+		cpy.PointsEarned = &earnedCopy
+	}
+
+	if a.Tags != nil {
+		cpy.Tags = make([]string, len(a.Tags))
+		copy(cpy.Tags, a.Tags)
+	}
+
+	if a.EstimatedHours != nil {
+		hoursCopy := *a.EstimatedHours
+		cpy.EstimatedHours = &hoursCopy
+	}
+
+	if a.Subtasks != nil {
+		cpy.Subtasks = make([]Subtask, len(a.Subtasks))
+		for i, subtask := range a.Subtasks {
+			cpy.Subtasks[i] = subtask.DeepCopy()
+		}
+	}
+
+	if a.Prerequisites != nil {
+		cpy.Prerequisites = make([]AssignmentRef, len(a.Prerequisites))
+		copy(cpy.Prerequisites, a.Prerequisites)
+	}
+
+	if a.Reminders != nil {
+		cpy.Reminders = make([]string, len(a.Reminders))
+		copy(cpy.Reminders, a.Reminders)
+	}
+
+	return cpy
 }
 
 func (a AssignmentItem) String() string {
@@ -78,7 +171,21 @@ func (a AssignmentItem) String() string {
 		infoStr = fmt.Sprintf("\n%s", *a.Info)
 	}
 
-	return fmt.Sprintf("%s%s\nDue: %s", a.Name, infoStr, a.DueAt.Format(DateFormat))
+	// Lines for whichever optional fields are set, each starting with a newline
+	extras := a.gradeString()
+	if len(a.Tags) > 0 {
+		extras += fmt.Sprintf("\nTags: %s", strings.Join(a.Tags, ", "))
+	}
+	if a.EstimatedHours != nil {
+		extras += fmt.Sprintf("\nEffort: %gh", *a.EstimatedHours)
+	}
+	extras += prerequisitesString(a.Prerequisites)
+	if len(a.Reminders) > 0 {
+		extras += "\nReminders: " + strings.Join(a.Reminders, ", ") + " before"
+	}
+	extras += a.subtasksString()
+
+	return fmt.Sprintf("%s%s\nDue: %s%s", a.Name, infoStr, a.DueAt.Format(DateFormat), extras)
 }
 
 type AssignmentList []AssignmentItem
@@ -100,7 +207,7 @@ func (l AssignmentList) String() string {
 func (l *AssignmentList) AddAssignment(name string, due string, info ...string) (bool, error) {
 	dueDate, err := time.Parse(DateFormat, due)
 	if err != nil {
-		return false, errors.New(InvalidDateErrMsg)
+		return false, invalidDateError()
 	}
 
 	var infoPtr *string
@@ -137,6 +244,34 @@ func (l *AssignmentList) ViewAssignment(index int) (AssignmentItem, error) {
 	return (*l)[index], nil
 }
 
+// Replaces the name, due date and info of the assignment at index, keeping its other fields and
+// re-inserting it so the list stays sorted by due date. The list is left unchanged if the new values are invalid.
+func (l *AssignmentList) ReplaceAssignment(index int, name string, due string, info ...string) (bool, error) {
+	if index < 0 || index >= len(*l) {
+		return false, errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	replacement := AssignmentList{}
+	_, err := replacement.AddAssignment(name, due, info...)
+	if err != nil {
+		return false, err
+	}
+
+	// Subtasks keep their dates, so an earlier due date can't leave one due after the assignment
+	for _, s := range (*l)[index].Subtasks {
+		if s.DueAt != nil && s.DueAt.After(replacement[0].DueAt) {
+			return false, fmt.Errorf("%s: `%s` is due %s", SubtaskDueAfterErrMsg, s.Name, s.DueAt.Format(DateFormat))
+		}
+	}
+
+	updated := (*l)[index]
+	updated.Name, updated.Info, updated.DueAt = replacement[0].Name, replacement[0].Info, replacement[0].DueAt
+
+	l.RemoveAssignment(index)
+	l.insertSorted(updated)
+	return true, nil
+}
+
 func (l *AssignmentList) insertSorted(newAssignment AssignmentItem) {
 	index := sort.Search(len(*l), func(i int) bool {
 		return (*l)[i].DueAt.After(newAssignment.DueAt)
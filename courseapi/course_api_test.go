@@ -32,7 +32,19 @@ func TestAddAssignment_InvalidDateFormat_Failure(t *testing.T) {
 	taskInfo := "Some new task"
 
 	_, err := l.AddAssignment(taskName, dueDate, taskInfo)
-	assert.EqualErrorf(t, err, InvalidDateErrMsg, "Error should be: %v, got: %v")
+	assert.EqualErrorf(t, err, InvalidDateErrMsg+" (please use MM/DD/YY)", "Error should be: %v, got: %v")
+}
+
+func TestAddAssignment_InvalidDate_ConfiguredFormat_Failure(t *testing.T) {
+	DateFormat, _ = ParseDatePattern("yyyy-mm-dd")
+	t.Cleanup(func() { DateFormat = DefaultDateFormat })
+
+	l := AssignmentList{}
+	_, err := l.AddAssignment("New Task", "02/21/25")
+	assert.EqualError(t, err, InvalidDateErrMsg+" (please use YYYY-MM-DD)")
+
+	_, err = l.AddAssignment("New Task", "2025-02-21")
+	assert.NoError(t, err)
 }
 
 func TestAddAssignment_TooManyInfoParams_Failure(t *testing.T) {
@@ -319,3 +331,39 @@ func TestAssignmentList_String_WithAssignments_Success(t *testing.T) {
 	expected := "1. Task 1\nDue: 02/02/25\n\n2. Task 2\nDue: 03/17/25\n"
 	assert.Equal(t, expected, assignments.String())
 }
+
+func TestReplaceAssignment_ChangeDueDate_Resorted_Success(t *testing.T) {
+	l := AssignmentList{}
+
+	l.AddAssignment("Task 1", "01/05/25")
+	l.AddAssignment("Task 2", "02/20/25")
+	l.AddAssignment("Task 3", "03/10/25")
+
+	_, err := l.ReplaceAssignment(0, "Task 1 (extended)", "04/01/25", "Extension granted")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(l))
+	assert.Equal(t, "Task 2", l[0].Name)
+	assert.Equal(t, "Task 1 (extended)", l[2].Name)
+	assert.Equal(t, "04/01/25", getDateFromTime(l[2].DueAt))
+	assert.Equal(t, "Extension granted", *l[2].Info)
+}
+
+func TestReplaceAssignment_InvalidDate_Unchanged_Failure(t *testing.T) {
+	l := AssignmentList{}
+	l.AddAssignment("Task 1", "01/05/25")
+
+	_, err := l.ReplaceAssignment(0, "Task 1", "31/31/25")
+
+	assert.EqualErrorf(t, err, InvalidDateErrMsg+" (please use MM/DD/YY)", "Error should be: %v, got: %v")
+	assert.Equal(t, 1, len(l))
+	assert.Equal(t, "01/05/25", getDateFromTime(l[0].DueAt))
+}
+
+func TestReplaceAssignment_OutOfBounds_Failure(t *testing.T) {
+	l := AssignmentList{}
+
+	_, err := l.ReplaceAssignment(0, "Task 1", "01/05/25")
+
+	assert.EqualErrorf(t, err, InvalidSliceRemoveErrMsg, "Error should be: %v, got: %v")
+}
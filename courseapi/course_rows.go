@@ -0,0 +1,72 @@
+package courseapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// A1 notation for cells within a tab, quoting the title since term names may contain spaces
+func TabRange(title string, cells string) string {
+	return fmt.Sprintf("'%s'!%s", strings.ReplaceAll(title, "'", "''"), cells)
+}
+
+// Names one tab of one spreadsheet, for state kept locally per term such as undo history and
+// delivered reminders
+func SheetScope(spreadsheetID string, tab string) string {
+	return spreadsheetID + "/" + tab
+}
+
+// Replaces every course row of the tab with cm, sorted by course name
+func RewriteCourseRows(ctx context.Context, srv *sheets.Service, spreadsheetID string, tab string, cm CourseMap) error {
+	names := make([]string, 0, len(cm))
+	for name := range cm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([][]interface{}, 0, len(names))
+	for _, name := range names {
+		jsonData, err := json.Marshal(cm[name])
+		if err != nil {
+			return fmt.Errorf("failed to encode CourseItem to JSON: %v", err)
+		}
+
+		values = append(values, []interface{}{name, string(jsonData)})
+	}
+
+	err := ReplaceRows(ctx, srv, spreadsheetID, tab, 1, values)
+	if err != nil {
+		return fmt.Errorf("failed to write courses: %v", err)
+	}
+
+	return nil
+}
+
+// Replaces the two-column rows of the tab from row first on with values. Rows past the new last
+// row are blanked by the same write rather than cleared beforehand, so if the write fails the tab
+// is left as it was.
+func ReplaceRows(ctx context.Context, srv *sheets.Service, spreadsheetID string, tab string, first int, values [][]interface{}) error {
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, TabRange(tab, fmt.Sprintf("A%d:B", first))).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	for len(values) < len(resp.Values) {
+		values = append(values, []interface{}{"", ""})
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	writeRange := TabRange(tab, fmt.Sprintf("A%d:B%d", first, first+len(values)-1))
+	_, err = srv.Spreadsheets.Values.Update(spreadsheetID, writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("RAW").Context(ctx).Do()
+
+	return err
+}
@@ -0,0 +1,122 @@
+package courseapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// A stand-in for the values endpoints of a single tab's A:B columns, keeping rows in memory
+type fakeSheet struct {
+	t         *testing.T
+	mu        sync.Mutex
+	rows      [][]interface{}
+	failWrite bool
+}
+
+func newTestSheet(t *testing.T, rows [][]interface{}) (*sheets.Service, *fakeSheet) {
+	fake := &fakeSheet{t: t, rows: rows}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	srv, err := sheets.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(server.Client()))
+	assert.NoError(t, err)
+
+	return srv, fake
+}
+
+func (f *fakeSheet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, "/v4/spreadsheets/sheet/values/'Fall 2026'!") {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(&sheets.ValueRange{Values: f.rows})
+	case http.MethodPut:
+		if f.failWrite {
+			http.Error(w, "backend error", http.StatusInternalServerError)
+			return
+		}
+
+		var body sheets.ValueRange
+		assert.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+
+		// Trailing blank rows aren't returned by reads, as in Google Sheets
+		f.rows = body.Values
+		for len(f.rows) > 0 && f.rows[len(f.rows)-1][0] == "" {
+			f.rows = f.rows[:len(f.rows)-1]
+		}
+		json.NewEncoder(w).Encode(&sheets.UpdateValuesResponse{})
+	default:
+		http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestRewriteCourseRows_Success(t *testing.T) {
+	srv, fake := newTestSheet(t, [][]interface{}{
+		{"BIO", `{"name": "BIO"}`},
+		{"CS101", `{"name": "CS101"}`},
+		{"MATH200", `{"name": "MATH200"}`},
+	})
+
+	cm := CourseMap{
+		"PHYS":  {Name: "PHYS", Assignments: AssignmentList{}},
+		"CS101": {Name: "CS101", Assignments: AssignmentList{}},
+	}
+	err := RewriteCourseRows(context.Background(), srv, "sheet", "Fall 2026", cm)
+	assert.NoError(t, err)
+
+	assert.Len(t, fake.rows, 2)
+	assert.Equal(t, "CS101", fake.rows[0][0])
+	assert.Equal(t, "PHYS", fake.rows[1][0])
+
+	err = RewriteCourseRows(context.Background(), srv, "sheet", "Fall 2026", CourseMap{})
+	assert.NoError(t, err)
+	assert.Empty(t, fake.rows)
+}
+
+func TestRewriteCourseRows_WriteFails_Failure(t *testing.T) {
+	rows := [][]interface{}{
+		{"BIO", `{"name": "BIO"}`},
+		{"CS101", `{"name": "CS101"}`},
+	}
+	srv, fake := newTestSheet(t, rows)
+	fake.failWrite = true
+
+	err := RewriteCourseRows(context.Background(), srv, "sheet", "Fall 2026", CourseMap{"PHYS": {Name: "PHYS"}})
+	assert.ErrorContains(t, err, "failed to write courses")
+
+	// The old rows survive a failed write
+	assert.Equal(t, rows, fake.rows)
+}
+
+func TestReplaceRows_BelowHeader_Success(t *testing.T) {
+	srv, fake := newTestSheet(t, [][]interface{}{{"Fall 2026", "false"}, {"Spring 2027", "false"}})
+
+	err := ReplaceRows(context.Background(), srv, "sheet", "Fall 2026", 2, [][]interface{}{{"Fall 2026", "true"}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{"Fall 2026", "true"}}, fake.rows)
+
+	fake.failWrite = true
+	err = ReplaceRows(context.Background(), srv, "sheet", "Fall 2026", 2, nil)
+	assert.Error(t, err)
+	assert.Equal(t, [][]interface{}{{"Fall 2026", "true"}}, fake.rows)
+}
+
+func TestTabRange_Success(t *testing.T) {
+	assert.Equal(t, "'Fall 2026'!A:B", TabRange("Fall 2026", "A:B"))
+	assert.Equal(t, "'Bob''s term'!A1", TabRange("Bob's term", "A1"))
+}
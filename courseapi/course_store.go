@@ -0,0 +1,205 @@
+package courseapi
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+const (
+	CourseStoreExistsErrMsg   = "course already exists in store"
+	CourseStoreNotFoundErrMsg = "course doesn't exist in store"
+)
+
+// A CourseMap that is safe for use from multiple goroutines. Every course going in or out
+// is deep-copied, so callers can freely edit what they read without affecting the store.
+type CourseStore struct {
+	mu      sync.RWMutex
+	courses CourseMap
+	// Bumped on every change, so derived data such as a search index knows when to rebuild
+	version uint64
+}
+
+func NewCourseStore(cm CourseMap) *CourseStore {
+	s := &CourseStore{courses: make(CourseMap, len(cm))}
+	for name, course := range cm {
+		cpy := course.DeepCopy()
+		s.courses[name] = &cpy
+	}
+
+	return s
+}
+
+func (s *CourseStore) Get(name string) (CourseItem, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	course, exists := s.courses[name]
+	if !exists {
+		return CourseItem{}, false
+	}
+
+	return course.DeepCopy(), true
+}
+
+func (s *CourseStore) Exists(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.courses[name]
+	return exists
+}
+
+func (s *CourseStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.courses)
+}
+
+// Returns the course names in sorted order
+func (s *CourseStore) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.courses))
+	for name := range s.courses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Returns a deep copy of every course, suitable for read-only work such as printing or exporting
+func (s *CourseStore) Snapshot() CourseMap {
+	cm, _ := s.SnapshotWithVersion()
+	return cm
+}
+
+// Like Snapshot, but also returns the version the snapshot was taken at
+func (s *CourseStore) SnapshotWithVersion() (CourseMap, uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cm := make(CourseMap, len(s.courses))
+	for name, course := range s.courses {
+		cpy := course.DeepCopy()
+		cm[name] = &cpy
+	}
+
+	return cm, s.version
+}
+
+// Changes whenever the store's contents might have changed
+func (s *CourseStore) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.version
+}
+
+// Adds a course that isn't in the store yet
+func (s *CourseStore) Add(course CourseItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.courses[course.Name]; exists {
+		return errors.New(CourseStoreExistsErrMsg)
+	}
+
+	cpy := course.DeepCopy()
+	s.courses[course.Name] = &cpy
+	s.version++
+	return nil
+}
+
+// Stores course unconditionally, replacing any existing course with the same name
+func (s *CourseStore) Put(course CourseItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cpy := course.DeepCopy()
+	s.courses[course.Name] = &cpy
+	s.version++
+}
+
+// Replaces the stored course with new only if it still equals old, i.e. nobody else has
+// changed it since old was read. Courses are compared in their stored JSON form. Reports
+// whether the swap happened.
+func (s *CourseStore) CompareAndSwap(old CourseItem, new CourseItem) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.courses[old.Name]
+	if !exists || !jsonEqual(*current, old.DeepCopy()) {
+		return false
+	}
+
+	cpy := new.DeepCopy()
+	delete(s.courses, old.Name)
+	s.courses[new.Name] = &cpy
+	s.version++
+	return true
+}
+
+// Removes the course only if it still equals old. Reports whether it was removed.
+func (s *CourseStore) CompareAndDelete(old CourseItem) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.courses[old.Name]
+	if !exists || !jsonEqual(*current, old.DeepCopy()) {
+		return false
+	}
+
+	delete(s.courses, old.Name)
+	s.version++
+	return true
+}
+
+// Removes a course, reporting whether it existed
+func (s *CourseStore) Delete(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.courses[name]
+	delete(s.courses, name)
+	if exists {
+		s.version++
+	}
+	return exists
+}
+
+// Atomically applies fn to a copy of the named course and stores the result, unless fn fails
+func (s *CourseStore) Update(name string, fn func(*CourseItem) error) (CourseItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.courses[name]
+	if !exists {
+		return CourseItem{}, errors.New(CourseStoreNotFoundErrMsg)
+	}
+
+	cpy := current.DeepCopy()
+	if err := fn(&cpy); err != nil {
+		return CourseItem{}, err
+	}
+
+	stored := cpy.DeepCopy()
+	delete(s.courses, name)
+	s.courses[stored.Name] = &stored
+	s.version++
+	return cpy, nil
+}
+
+// Replaces the entire contents of the store, e.g. after reloading from sheets
+func (s *CourseStore) Reset(cm CourseMap) {
+	fresh := NewCourseStore(cm)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.courses = fresh.courses
+	s.version++
+}
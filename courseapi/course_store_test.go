@@ -0,0 +1,202 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore() *CourseStore {
+	info := "Intro to CS"
+	return NewCourseStore(CourseMap{
+		"CS101": {Name: "CS101", Course_Info: &info, Assignments: AssignmentList{}},
+	})
+}
+
+func TestCourseStore_Get_ReturnsCopy_Success(t *testing.T) {
+	s := newTestStore()
+
+	course, exists := s.Get("CS101")
+	assert.True(t, exists)
+
+	*course.Course_Info = "Changed"
+	course.Assignments.AddAssignment("Lab 1", "02/02/25")
+
+	stored, _ := s.Get("CS101")
+	assert.Equal(t, "Intro to CS", *stored.Course_Info)
+	assert.Equal(t, 0, len(stored.Assignments))
+}
+
+func TestCourseStore_Get_Missing_Failure(t *testing.T) {
+	s := newTestStore()
+
+	_, exists := s.Get("MATH100")
+	assert.False(t, exists)
+}
+
+func TestCourseStore_NewCourseStore_CopiesInput_Success(t *testing.T) {
+	cm := CourseMap{"CS101": {Name: "CS101"}}
+	s := NewCourseStore(cm)
+
+	cm["CS101"].Name = "Changed"
+
+	course, _ := s.Get("CS101")
+	assert.Equal(t, "CS101", course.Name)
+}
+
+func TestCourseStore_Add_Duplicate_Failure(t *testing.T) {
+	s := newTestStore()
+
+	err := s.Add(CourseItem{Name: "CS101"})
+	assert.EqualError(t, err, CourseStoreExistsErrMsg)
+
+	err = s.Add(CourseItem{Name: "MATH100"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CS101", "MATH100"}, s.Names())
+}
+
+func TestCourseStore_CompareAndSwap_Unchanged_Success(t *testing.T) {
+	s := newTestStore()
+
+	old, _ := s.Get("CS101")
+	updated := old.DeepCopy()
+	updated.Assignments.AddAssignment("Lab 1", "02/02/25")
+
+	assert.True(t, s.CompareAndSwap(old, updated))
+
+	stored, _ := s.Get("CS101")
+	assert.Equal(t, 1, len(stored.Assignments))
+}
+
+func TestCourseStore_CompareAndSwap_StaleRead_Failure(t *testing.T) {
+	s := newTestStore()
+
+	old, _ := s.Get("CS101")
+
+	concurrent := old.DeepCopy()
+	concurrent.Assignments.AddAssignment("Lab 1", "02/02/25")
+	s.Put(concurrent)
+
+	updated := old.DeepCopy()
+	updated.Assignments.AddAssignment("Lab 2", "02/09/25")
+
+	assert.False(t, s.CompareAndSwap(old, updated))
+
+	stored, _ := s.Get("CS101")
+	assert.Equal(t, "Lab 1", stored.Assignments[0].Name)
+}
+
+func TestCourseStore_CompareAndDelete_StaleRead_Failure(t *testing.T) {
+	s := newTestStore()
+
+	old, _ := s.Get("CS101")
+	changed := old.DeepCopy()
+	changed.Course_Info = nil
+	s.Put(changed)
+
+	assert.False(t, s.CompareAndDelete(old))
+	assert.True(t, s.CompareAndDelete(changed))
+	assert.False(t, s.Exists("CS101"))
+}
+
+func TestCourseStore_Update_FnError_Unchanged_Failure(t *testing.T) {
+	s := newTestStore()
+
+	_, err := s.Update("CS101", func(c *CourseItem) error {
+		c.Assignments.AddAssignment("Lab 1", "02/02/25")
+		return errors.New("nope")
+	})
+	assert.EqualError(t, err, "nope")
+
+	stored, _ := s.Get("CS101")
+	assert.Equal(t, 0, len(stored.Assignments))
+
+	_, err = s.Update("MATH100", func(c *CourseItem) error { return nil })
+	assert.EqualError(t, err, CourseStoreNotFoundErrMsg)
+}
+
+func TestCourseStore_Reset_ReplacesContents_Success(t *testing.T) {
+	s := newTestStore()
+
+	s.Reset(CourseMap{"MATH100": {Name: "MATH100"}})
+
+	assert.Equal(t, []string{"MATH100"}, s.Names())
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestCourseStore_Version_ChangesOnlyOnWrites_Success(t *testing.T) {
+	s := newTestStore()
+	version := s.Version()
+
+	s.Get("CS101")
+	s.Delete("MATH100")
+	assert.False(t, s.CompareAndDelete(CourseItem{Name: "CS101", Assignments: AssignmentList{{Name: "Stale"}}}))
+	assert.Equal(t, version, s.Version())
+
+	s.Put(CourseItem{Name: "MATH100"})
+	_, snapshotVersion := s.SnapshotWithVersion()
+	assert.Equal(t, version+1, snapshotVersion)
+}
+
+// Run with `go test -race` to have the race detector verify these
+func TestCourseStore_ConcurrentUpdates_NoLostWrites_Success(t *testing.T) {
+	s := newTestStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			s.Update("CS101", func(c *CourseItem) error {
+				_, err := c.Assignments.AddAssignment(fmt.Sprintf("Task %d", i), "02/02/25")
+				return err
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	stored, _ := s.Get("CS101")
+	assert.Equal(t, 50, len(stored.Assignments))
+}
+
+func TestCourseStore_ConcurrentCompareAndSwap_NoLostWrites_Success(t *testing.T) {
+	s := newTestStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for {
+				old, _ := s.Get("CS101")
+				updated := old.DeepCopy()
+				updated.Assignments.AddAssignment(fmt.Sprintf("Task %d", i), "02/02/25")
+
+				if s.CompareAndSwap(old, updated) {
+					return
+				}
+			}
+		}(i)
+	}
+
+	// Readers racing with the writers above
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			snapshot := s.Snapshot()
+			_ = snapshot.String()
+			_ = s.Names()
+		}()
+	}
+	wg.Wait()
+
+	stored, _ := s.Get("CS101")
+	assert.Equal(t, 20, len(stored.Assignments))
+}
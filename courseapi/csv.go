@@ -0,0 +1,267 @@
+package courseapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	CSVMissingHeaderErrMsg   = "csv is missing a header row"
+	CSVMissingColumnErrMsg   = "csv header is missing required column"
+	CSVDuplicateColumnErrMsg = "csv header maps more than one column to"
+	CSVUnknownCourseErrMsg   = "course doesn't exist"
+	CSVEmptyFieldErrMsg      = "required field is empty"
+)
+
+// Canonical column names used for CSV import and export
+const (
+	CSVCourseColumn = "course"
+	CSVNameColumn   = "name"
+	CSVDueColumn    = "due_date"
+	CSVInfoColumn   = "info"
+)
+
+// Header aliases accepted on import, keyed by their normalized spelling
+var csvHeaderAliases = map[string]string{
+	"course":          CSVCourseColumn,
+	"course_name":     CSVCourseColumn,
+	"name":            CSVNameColumn,
+	"assignment":      CSVNameColumn,
+	"assignment_name": CSVNameColumn,
+	"due":             CSVDueColumn,
+	"due_date":        CSVDueColumn,
+	"due_at":          CSVDueColumn,
+	"info":            CSVInfoColumn,
+	"notes":           CSVInfoColumn,
+	"assignment_info": CSVInfoColumn,
+}
+
+// A single validated row of an assignment CSV
+type CSVRecord struct {
+	Line   int
+	Course string
+	Name   string
+	Due    string
+	Info   string
+}
+
+type CSVLineError struct {
+	Line int
+	Err  error
+}
+
+func (e CSVLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Collects every per-line problem found during an import so they can be reported together
+type CSVImportError struct {
+	Errors []CSVLineError
+}
+
+func (e *CSVImportError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, lineErr := range e.Errors {
+		lines[i] = lineErr.Error()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func normalizeCSVHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	header = strings.NewReplacer(" ", "_", "-", "_").Replace(header)
+
+	return header
+}
+
+// Maps canonical column names to their index within the header row, ignoring unknown columns
+func mapCSVHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+
+	for i, h := range header {
+		column, known := csvHeaderAliases[normalizeCSVHeader(h)]
+		if !known {
+			continue
+		}
+
+		if _, exists := columns[column]; exists {
+			return nil, fmt.Errorf("%s `%s`", CSVDuplicateColumnErrMsg, column)
+		}
+		columns[column] = i
+	}
+
+	for _, required := range []string{CSVCourseColumn, CSVNameColumn, CSVDueColumn} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("%s `%s`", CSVMissingColumnErrMsg, required)
+		}
+	}
+
+	return columns, nil
+}
+
+// Reads assignment rows from a CSV with a header row. Rows that can't be read are reported
+// together as a *CSVImportError rather than stopping at the first bad line.
+func ParseAssignmentsCSV(r io.Reader) ([]CSVRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New(CSVMissingHeaderErrMsg)
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns, err := mapCSVHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	field := func(row []string, column string) string {
+		i, exists := columns[column]
+		if !exists || i >= len(row) {
+			return ""
+		}
+
+		return strings.TrimSpace(row[i])
+	}
+
+	var records []CSVRecord
+	importErr := &CSVImportError{}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				importErr.Errors = append(importErr.Errors, CSVLineError{Line: parseErr.Line, Err: parseErr.Err})
+				continue
+			}
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := CSVRecord{
+			Line:   line,
+			Course: field(row, CSVCourseColumn),
+			Name:   field(row, CSVNameColumn),
+			Due:    field(row, CSVDueColumn),
+			Info:   field(row, CSVInfoColumn),
+		}
+
+		if record.Course == "" && record.Name == "" && record.Due == "" && record.Info == "" {
+			continue // Skip blank lines
+		}
+
+		for _, required := range []struct{ column, value string }{
+			{CSVCourseColumn, record.Course},
+			{CSVNameColumn, record.Name},
+			{CSVDueColumn, record.Due},
+		} {
+			if required.value == "" {
+				importErr.Errors = append(importErr.Errors, CSVLineError{
+					Line: line,
+					Err:  fmt.Errorf("%s `%s`", CSVEmptyFieldErrMsg, required.column),
+				})
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	if len(importErr.Errors) > 0 {
+		return nil, importErr
+	}
+
+	return records, nil
+}
+
+// Applies records to deep copies of the affected courses, leaving cm untouched. Either every
+// record is valid and the updated copies are returned, or a *CSVImportError lists every failure.
+func (cm CourseMap) ApplyCSVRecords(records []CSVRecord) (CourseMap, error) {
+	updated := make(CourseMap)
+	importErr := &CSVImportError{}
+
+	for _, record := range records {
+		course, exists := updated[record.Course]
+		if !exists {
+			original, found := cm[record.Course]
+			if !found {
+				importErr.Errors = append(importErr.Errors, CSVLineError{
+					Line: record.Line,
+					Err:  fmt.Errorf("%s: `%s`", CSVUnknownCourseErrMsg, record.Course),
+				})
+				continue
+			}
+
+			cpy := original.DeepCopy()
+			course = &cpy
+			updated[record.Course] = course
+		}
+
+		var err error
+		if record.Info == "" {
+			_, err = course.Assignments.AddAssignment(record.Name, record.Due)
+		} else {
+			_, err = course.Assignments.AddAssignment(record.Name, record.Due, record.Info)
+		}
+
+		if err != nil {
+			importErr.Errors = append(importErr.Errors, CSVLineError{Line: record.Line, Err: err})
+		}
+	}
+
+	if len(importErr.Errors) > 0 {
+		return nil, importErr
+	}
+
+	return updated, nil
+}
+
+// Writes every assignment of the given courses (or all courses, if none are named) as CSV
+// in the same column layout that ParseAssignmentsCSV accepts
+func (cm CourseMap) WriteAssignmentsCSV(w io.Writer, courseNames ...string) error {
+	if len(courseNames) == 0 {
+		for name := range cm {
+			courseNames = append(courseNames, name)
+		}
+		sort.Strings(courseNames)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{CSVCourseColumn, CSVNameColumn, CSVDueColumn, CSVInfoColumn}); err != nil {
+		return err
+	}
+
+	for _, name := range courseNames {
+		course, exists := cm[name]
+		if !exists {
+			return fmt.Errorf("%s: `%s`", CSVUnknownCourseErrMsg, name)
+		}
+
+		for _, assignment := range course.Assignments {
+			info := ""
+			if assignment.Info != nil {
+				info = *assignment.Info
+			}
+
+			row := []string{course.Name, assignment.Name, assignment.DueAt.Format(DateFormat), info}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
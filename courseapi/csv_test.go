@@ -0,0 +1,136 @@
+package courseapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAssignmentsCSV_SimpleParse_Success(t *testing.T) {
+	input := "course,name,due_date,info\nCS101,Lab 1,02/02/25,Bring laptop\nCS101,Lab 2,02/09/25,\n"
+
+	records, err := ParseAssignmentsCSV(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, CSVRecord{Line: 2, Course: "CS101", Name: "Lab 1", Due: "02/02/25", Info: "Bring laptop"}, records[0])
+	assert.Equal(t, CSVRecord{Line: 3, Course: "CS101", Name: "Lab 2", Due: "02/09/25"}, records[1])
+}
+
+func TestParseAssignmentsCSV_HeaderAliasesAndOrder_Success(t *testing.T) {
+	input := "Notes,Due Date,Assignment Name,Course Name,Extra\nRead ch. 1,03/01/25,Reading 1,HIST200,ignored\n"
+
+	records, err := ParseAssignmentsCSV(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []CSVRecord{{Line: 2, Course: "HIST200", Name: "Reading 1", Due: "03/01/25", Info: "Read ch. 1"}}, records)
+}
+
+func TestParseAssignmentsCSV_MissingColumn_Failure(t *testing.T) {
+	input := "course,name\nCS101,Lab 1\n"
+
+	_, err := ParseAssignmentsCSV(strings.NewReader(input))
+
+	assert.ErrorContains(t, err, CSVMissingColumnErrMsg)
+}
+
+func TestParseAssignmentsCSV_DuplicateColumn_Failure(t *testing.T) {
+	input := "course,name,due,due_date\n"
+
+	_, err := ParseAssignmentsCSV(strings.NewReader(input))
+
+	assert.ErrorContains(t, err, CSVDuplicateColumnErrMsg)
+}
+
+func TestParseAssignmentsCSV_EmptyInput_Failure(t *testing.T) {
+	_, err := ParseAssignmentsCSV(strings.NewReader(""))
+
+	assert.EqualError(t, err, CSVMissingHeaderErrMsg)
+}
+
+func TestParseAssignmentsCSV_EmptyFields_ReportsEveryLine(t *testing.T) {
+	input := "course,name,due_date\nCS101,,02/02/25\nCS101,Lab 2,02/09/25\n,Lab 3,\n"
+
+	_, err := ParseAssignmentsCSV(strings.NewReader(input))
+
+	importErr, ok := err.(*CSVImportError)
+	assert.True(t, ok)
+	assert.Equal(t, 3, len(importErr.Errors))
+	assert.Equal(t, 2, importErr.Errors[0].Line)
+	assert.Equal(t, 4, importErr.Errors[1].Line)
+	assert.Equal(t, 4, importErr.Errors[2].Line)
+}
+
+func TestCourseMap_ApplyCSVRecords_SimpleApply_Success(t *testing.T) {
+	cm := CourseMap{"CS101": {Name: "CS101", Assignments: AssignmentList{}}}
+	records := []CSVRecord{
+		{Line: 2, Course: "CS101", Name: "Lab 2", Due: "02/09/25"},
+		{Line: 3, Course: "CS101", Name: "Lab 1", Due: "02/02/25", Info: "Bring laptop"},
+	}
+
+	updated, err := cm.ApplyCSVRecords(records)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(updated["CS101"].Assignments))
+	assert.Equal(t, "Lab 1", updated["CS101"].Assignments[0].Name)
+	assert.Equal(t, "Bring laptop", *updated["CS101"].Assignments[0].Info)
+	assert.Nil(t, updated["CS101"].Assignments[1].Info)
+
+	// The original map must be untouched until the caller commits the batch
+	assert.Equal(t, 0, len(cm["CS101"].Assignments))
+}
+
+func TestCourseMap_ApplyCSVRecords_InvalidRows_NothingApplied(t *testing.T) {
+	cm := CourseMap{"CS101": {Name: "CS101", Assignments: AssignmentList{}}}
+	records := []CSVRecord{
+		{Line: 2, Course: "CS101", Name: "Lab 1", Due: "02/02/25"},
+		{Line: 3, Course: "CS101", Name: "Lab 2", Due: "13/40/25"},
+		{Line: 4, Course: "MATH100", Name: "HW 1", Due: "02/02/25"},
+	}
+
+	updated, err := cm.ApplyCSVRecords(records)
+
+	assert.Nil(t, updated)
+	importErr, ok := err.(*CSVImportError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(importErr.Errors))
+	assert.EqualError(t, importErr.Errors[0], "line 3: "+invalidDateError().Error())
+	assert.ErrorContains(t, importErr.Errors[1], CSVUnknownCourseErrMsg)
+	assert.Equal(t, 0, len(cm["CS101"].Assignments))
+}
+
+func TestCourseMap_WriteAssignmentsCSV_RoundTrip_Success(t *testing.T) {
+	info := "Bring laptop"
+	cm := CourseMap{
+		"CS101": {Name: "CS101", Assignments: AssignmentList{
+			{Name: "Lab 1", Info: &info, DueAt: time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)},
+		}},
+		"ART100": {Name: "ART100", Assignments: AssignmentList{
+			{Name: "Sketch, then paint", DueAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	var buf bytes.Buffer
+	err := cm.WriteAssignmentsCSV(&buf)
+
+	assert.NoError(t, err)
+	expected := "course,name,due_date,info\nART100,\"Sketch, then paint\",03/01/25,\nCS101,Lab 1,02/02/25,Bring laptop\n"
+	assert.Equal(t, expected, buf.String())
+
+	records, err := ParseAssignmentsCSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "Sketch, then paint", records[0].Name)
+}
+
+func TestCourseMap_WriteAssignmentsCSV_UnknownCourse_Failure(t *testing.T) {
+	cm := CourseMap{}
+
+	var buf bytes.Buffer
+	err := cm.WriteAssignmentsCSV(&buf, "CS101")
+
+	assert.ErrorContains(t, err, CSVUnknownCourseErrMsg)
+}
@@ -0,0 +1,230 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	SelfPrerequisiteErrMsg     = "an assignment can't be its own prerequisite"
+	PrerequisiteExistsErrMsg   = "assignment already has this prerequisite"
+	PrerequisiteCycleErrMsg    = "prerequisite would create a cycle"
+	AmbiguousAssignmentErrMsg  = "another assignment in the course has the same name, rename one first"
+	InvalidPrerequisiteErrMsg  = "no prerequisite at that number"
+	UnknownAssignmentRefErrMsg = "assignment doesn't exist"
+
+	// A prerequisite still open this close to its dependent's deadline is flagged
+	DefaultPrerequisiteWarningDays = 3
+)
+
+// Points at an assignment by course and name, since assignment numbers shift as due dates change
+type AssignmentRef struct {
+	Course string `json:"course"`
+	Name   string `json:"name"`
+}
+
+func (r AssignmentRef) String() string {
+	return fmt.Sprintf("%s `%s`", r.Course, r.Name)
+}
+
+// Whether the assignment is finished: graded, or with every subtask checked off
+func (a AssignmentItem) IsComplete() bool {
+	if a.IsGraded() {
+		return true
+	}
+
+	_, ok := a.Progress()
+	return ok && a.subtasksDone() == len(a.Subtasks)
+}
+
+// Finds the assignment a ref points at, failing if none or several share its name
+func (cm CourseMap) resolve(ref AssignmentRef) (*AssignmentItem, error) {
+	course, exists := cm[ref.Course]
+	if !exists {
+		return nil, fmt.Errorf("%s: %s", ref.String(), UnknownAssignmentRefErrMsg)
+	}
+
+	var found *AssignmentItem
+	for i := range course.Assignments {
+		if course.Assignments[i].Name != ref.Name {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("%s: %s", ref.String(), AmbiguousAssignmentErrMsg)
+		}
+		found = &course.Assignments[i]
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%s: %s", ref.String(), UnknownAssignmentRefErrMsg)
+	}
+
+	return found, nil
+}
+
+func (cm CourseMap) refAt(courseName string, index int) (AssignmentRef, error) {
+	course, exists := cm[courseName]
+	if !exists {
+		return AssignmentRef{}, fmt.Errorf("course `%s` doesn't exist", courseName)
+	}
+
+	if index < 0 || index >= len(course.Assignments) {
+		return AssignmentRef{}, errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	ref := AssignmentRef{Course: courseName, Name: course.Assignments[index].Name}
+	if _, err := cm.resolve(ref); err != nil {
+		return AssignmentRef{}, err
+	}
+
+	return ref, nil
+}
+
+// Whether to can be reached from from by following prerequisites
+func (cm CourseMap) dependsOn(from AssignmentRef, to AssignmentRef, visited map[AssignmentRef]bool) bool {
+	if from == to {
+		return true
+	}
+
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	a, err := cm.resolve(from)
+	if err != nil {
+		return false
+	}
+
+	for _, prereq := range a.Prerequisites {
+		if cm.dependsOn(prereq, to, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Makes the assignment at index in the named course depend on the assignment at prereqIndex in
+// prereqCourse. Only the dependent's course is changed. Fails if this would create a cycle.
+func (cm CourseMap) AddPrerequisite(courseName string, index int, prereqCourse string, prereqIndex int) error {
+	dependent, err := cm.refAt(courseName, index)
+	if err != nil {
+		return err
+	}
+
+	prereq, err := cm.refAt(prereqCourse, prereqIndex)
+	if err != nil {
+		return err
+	}
+
+	if dependent == prereq {
+		return errors.New(SelfPrerequisiteErrMsg)
+	}
+
+	a := &cm[courseName].Assignments[index]
+	for _, existing := range a.Prerequisites {
+		if existing == prereq {
+			return errors.New(PrerequisiteExistsErrMsg)
+		}
+	}
+
+	if cm.dependsOn(prereq, dependent, make(map[AssignmentRef]bool)) {
+		return errors.New(PrerequisiteCycleErrMsg)
+	}
+
+	a.Prerequisites = append(a.Prerequisites, prereq)
+	return nil
+}
+
+// Removes the prerequisite at prereq (0-based, in the order they were added) from the assignment at index
+func (c *CourseItem) RemovePrerequisite(index int, prereq int) error {
+	a, err := c.assignment(index)
+	if err != nil {
+		return err
+	}
+
+	if prereq < 0 || prereq >= len(a.Prerequisites) {
+		return errors.New(InvalidPrerequisiteErrMsg)
+	}
+
+	a.Prerequisites = append(a.Prerequisites[:prereq], a.Prerequisites[prereq+1:]...)
+	if len(a.Prerequisites) == 0 {
+		a.Prerequisites = nil
+	}
+	return nil
+}
+
+// A problem with an assignment's prerequisite
+type DependencyWarning struct {
+	Dependent AssignmentRef
+	Prereq    AssignmentRef
+	DueAt     time.Time
+	Msg       string
+}
+
+func (w DependencyWarning) String() string {
+	return fmt.Sprintf("%s (due %s) needs %s: %s", w.Dependent.String(), w.DueAt.Format(DateFormat), w.Prereq.String(), w.Msg)
+}
+
+// Checks every prerequisite across cm, warning when one no longer exists, is due after the
+// assignment depending on it, or is still incomplete within warnDays of that assignment's
+// deadline. Complete assignments don't warn about their prerequisites.
+func (cm CourseMap) DependencyWarnings(now time.Time, warnDays int) []DependencyWarning {
+	today := filterToday(now)
+	var warnings []DependencyWarning
+
+	for courseName, course := range cm {
+		for _, a := range course.Assignments {
+			if a.IsComplete() {
+				continue
+			}
+
+			dependent := AssignmentRef{Course: courseName, Name: a.Name}
+			for _, ref := range a.Prerequisites {
+				warning := DependencyWarning{Dependent: dependent, Prereq: ref, DueAt: a.DueAt}
+
+				prereq, err := cm.resolve(ref)
+				switch {
+				case err != nil:
+					warning.Msg = "prerequisite can't be found (was it renamed or removed?)"
+				case prereq.DueAt.After(a.DueAt):
+					warning.Msg = fmt.Sprintf("prerequisite isn't due until %s, after the assignment itself", prereq.DueAt.Format(DateFormat))
+				case !prereq.IsComplete() && !a.DueAt.After(today.AddDate(0, 0, warnDays)):
+					warning.Msg = fmt.Sprintf("prerequisite is still open with %d day(s) left", int(a.DueAt.Sub(today).Hours()/24))
+				default:
+					continue
+				}
+
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+
+	sort.Slice(warnings, func(i, j int) bool {
+		a, b := warnings[i], warnings[j]
+		if !a.DueAt.Equal(b.DueAt) {
+			return a.DueAt.Before(b.DueAt)
+		}
+		return a.String() < b.String()
+	})
+
+	return warnings
+}
+
+func prerequisitesString(prereqs []AssignmentRef) string {
+	if len(prereqs) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(prereqs))
+	for i, ref := range prereqs {
+		parts[i] = fmt.Sprintf("%d. %s", i+1, ref.String())
+	}
+
+	return "\nNeeds: " + strings.Join(parts, ", ")
+}
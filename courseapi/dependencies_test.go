@@ -0,0 +1,88 @@
+package courseapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCourseMap_AddPrerequisite_Success(t *testing.T) {
+	cm := newTestTerm()
+
+	assert.NoError(t, cm.AddPrerequisite("CS101", 2, "CS101", 1))
+	assert.NoError(t, cm.AddPrerequisite("CS101", 2, "MATH200", 1))
+
+	midterm := cm["CS101"].Assignments[2]
+	assert.Equal(t, []AssignmentRef{{Course: "CS101", Name: "Lab 2"}, {Course: "MATH200", Name: "Midterm"}}, midterm.Prerequisites)
+	assert.Equal(t, "Midterm\nDue: 03/01/25\nCategory: Exams\nPoints: 100\nTags: exam\nNeeds: 1. CS101 `Lab 2`, 2. MATH200 `Midterm`", midterm.String())
+
+	cpy := cm["CS101"].DeepCopy()
+	cpy.Assignments[2].Prerequisites[0].Name = "Lab 1"
+	assert.Equal(t, "Lab 2", cm["CS101"].Assignments[2].Prerequisites[0].Name)
+}
+
+func TestCourseMap_AddPrerequisite_Failure(t *testing.T) {
+	cm := newTestTerm()
+	assert.NoError(t, cm.AddPrerequisite("CS101", 2, "CS101", 1))
+	assert.NoError(t, cm.AddPrerequisite("CS101", 1, "CS101", 0))
+
+	assert.EqualError(t, cm.AddPrerequisite("CS101", 0, "CS101", 2), PrerequisiteCycleErrMsg)
+	assert.EqualError(t, cm.AddPrerequisite("CS101", 1, "CS101", 1), SelfPrerequisiteErrMsg)
+	assert.EqualError(t, cm.AddPrerequisite("CS101", 2, "CS101", 1), PrerequisiteExistsErrMsg)
+	assert.EqualError(t, cm.AddPrerequisite("CS101", 2, "CS101", 4), InvalidSliceRemoveErrMsg)
+	assert.Error(t, cm.AddPrerequisite("CS101", 2, "PHYS100", 0))
+
+	cm["MATH200"].Assignments = append(cm["MATH200"].Assignments, cm["MATH200"].Assignments[1])
+	assert.EqualError(t, cm.AddPrerequisite("CS101", 2, "MATH200", 2), "MATH200 `Midterm`: "+AmbiguousAssignmentErrMsg)
+	assert.Nil(t, cm["CS101"].Assignments[0].Prerequisites)
+}
+
+func TestCourseItem_RemovePrerequisite_Success(t *testing.T) {
+	cm := newTestTerm()
+	assert.NoError(t, cm.AddPrerequisite("CS101", 2, "CS101", 1))
+
+	assert.EqualError(t, cm["CS101"].RemovePrerequisite(2, 1), InvalidPrerequisiteErrMsg)
+	assert.NoError(t, cm["CS101"].RemovePrerequisite(2, 0))
+	assert.Nil(t, cm["CS101"].Assignments[2].Prerequisites)
+}
+
+func TestCourseMap_DependencyWarnings_Success(t *testing.T) {
+	cm := newTestTerm()
+	// CS101's midterm needs Lab 2 and MATH200's midterm; Lab 2 needs MATH200's midterm, which is due after it
+	assert.NoError(t, cm.AddPrerequisite("CS101", 2, "CS101", 1))
+	assert.NoError(t, cm.AddPrerequisite("CS101", 2, "MATH200", 1))
+	assert.NoError(t, cm.AddPrerequisite("CS101", 1, "MATH200", 1))
+
+	now := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	warnings := cm.DependencyWarnings(now, DefaultPrerequisiteWarningDays)
+	assert.Equal(t, 1, len(warnings))
+	assert.Equal(t, "CS101 `Lab 2` (due 02/09/25) needs MATH200 `Midterm`: prerequisite isn't due until 02/12/25, after the assignment itself", warnings[0].String())
+
+	// Close to CS101's midterm, both of its prerequisites are still open
+	now = time.Date(2025, 2, 27, 12, 0, 0, 0, time.UTC)
+	warnings = cm.DependencyWarnings(now, DefaultPrerequisiteWarningDays)
+	assert.Equal(t, 3, len(warnings))
+	assert.Equal(t, "CS101 `Midterm` (due 03/01/25) needs CS101 `Lab 2`: prerequisite is still open with 2 day(s) left", warnings[1].String())
+
+	// Finished prerequisites stop warning, and so do finished dependents
+	cm["CS101"].RecordScore(1, 10)
+	cm["MATH200"].Assignments[1].Name = "Midterm 1"
+	warnings = cm.DependencyWarnings(now, DefaultPrerequisiteWarningDays)
+	assert.Equal(t, 1, len(warnings))
+	assert.Equal(t, "CS101 `Midterm` (due 03/01/25) needs MATH200 `Midterm`: prerequisite can't be found (was it renamed or removed?)", warnings[0].String())
+}
+
+func TestAssignmentItem_IsComplete_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Project", "04/30/25"})
+	course.AddSubtask(0, "Proposal", "02/15/25")
+	course.AddSubtask(0, "Draft", "03/30/25")
+
+	assert.False(t, course.Assignments[0].IsComplete())
+
+	for i := range course.Assignments[0].Subtasks {
+		assert.NoError(t, course.SetSubtaskDone(0, i, true))
+	}
+	assert.True(t, course.Assignments[0].IsComplete())
+	assert.False(t, AssignmentItem{}.IsComplete())
+}
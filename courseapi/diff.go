@@ -0,0 +1,164 @@
+package courseapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type AssignmentChange struct {
+	Old AssignmentItem
+	New AssignmentItem
+}
+
+// Differences within a course present on both sides of a diff
+type CourseDiff struct {
+	Name               string
+	InfoChanged        bool
+	AddedAssignments   []AssignmentItem
+	RemovedAssignments []AssignmentItem
+	ChangedAssignments []AssignmentChange
+}
+
+func (d CourseDiff) IsEmpty() bool {
+	return !d.InfoChanged && len(d.AddedAssignments) == 0 && len(d.RemovedAssignments) == 0 && len(d.ChangedAssignments) == 0
+}
+
+// Everything that differs between two CourseMaps, e.g. local data and a fresh copy from sheets
+type CourseMapDiff struct {
+	AddedCourses   []string
+	RemovedCourses []string
+	ChangedCourses []CourseDiff
+}
+
+func (d CourseMapDiff) IsEmpty() bool {
+	return len(d.AddedCourses) == 0 && len(d.RemovedCourses) == 0 && len(d.ChangedCourses) == 0
+}
+
+func (d CourseMapDiff) String() string {
+	if d.IsEmpty() {
+		return "No changes."
+	}
+
+	result := ""
+	for _, name := range d.AddedCourses {
+		result += fmt.Sprintf("+ Course `%s` added\n", name)
+	}
+	for _, name := range d.RemovedCourses {
+		result += fmt.Sprintf("- Course `%s` removed\n", name)
+	}
+
+	for _, course := range d.ChangedCourses {
+		result += fmt.Sprintf("~ Course `%s` changed\n", course.Name)
+		if course.InfoChanged {
+			result += "    ~ Course info changed\n"
+		}
+		for _, a := range course.AddedAssignments {
+			result += fmt.Sprintf("    + Assignment `%s` (due %s) added\n", a.Name, a.DueAt.Format(DateFormat))
+		}
+		for _, a := range course.RemovedAssignments {
+			result += fmt.Sprintf("    - Assignment `%s` (due %s) removed\n", a.Name, a.DueAt.Format(DateFormat))
+		}
+		for _, change := range course.ChangedAssignments {
+			result += fmt.Sprintf("    ~ Assignment `%s` changed", change.New.Name)
+			if !change.Old.DueAt.Equal(change.New.DueAt) {
+				result += fmt.Sprintf(" (due %s -> %s)", change.Old.DueAt.Format(DateFormat), change.New.DueAt.Format(DateFormat))
+			}
+			result += "\n"
+		}
+	}
+
+	return strings.TrimSuffix(result, "\n")
+}
+
+// Compares through JSON, the form courses are stored in, so newly added fields are covered automatically
+func jsonEqual(a any, b any) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// Assignments have no IDs, so they are matched up by name (in due date order when a name repeats)
+func diffAssignments(old AssignmentList, new AssignmentList) ([]AssignmentItem, []AssignmentItem, []AssignmentChange) {
+	oldByName := make(map[string][]AssignmentItem)
+	for _, a := range old {
+		oldByName[a.Name] = append(oldByName[a.Name], a)
+	}
+
+	var added []AssignmentItem
+	var changed []AssignmentChange
+
+	for _, a := range new {
+		candidates := oldByName[a.Name]
+		if len(candidates) == 0 {
+			added = append(added, a)
+			continue
+		}
+
+		match := candidates[0]
+		oldByName[a.Name] = candidates[1:]
+
+		if !jsonEqual(match, a) {
+			changed = append(changed, AssignmentChange{Old: match, New: a})
+		}
+	}
+
+	var removed []AssignmentItem
+	for _, a := range old {
+		if remaining := oldByName[a.Name]; len(remaining) > 0 && jsonEqual(remaining[0], a) {
+			removed = append(removed, a)
+			oldByName[a.Name] = remaining[1:]
+		}
+	}
+
+	return added, removed, changed
+}
+
+func DiffCourses(old CourseItem, new CourseItem) CourseDiff {
+	added, removed, changed := diffAssignments(old.Assignments, new.Assignments)
+
+	oldInfo, newInfo := old.DeepCopy(), new.DeepCopy()
+	oldInfo.Assignments, newInfo.Assignments = nil, nil
+
+	return CourseDiff{
+		Name:               new.Name,
+		InfoChanged:        !jsonEqual(oldInfo, newInfo),
+		AddedAssignments:   added,
+		RemovedAssignments: removed,
+		ChangedAssignments: changed,
+	}
+}
+
+// Reports what changed going from old to new, with course names in sorted order
+func DiffCourseMaps(old CourseMap, new CourseMap) CourseMapDiff {
+	var diff CourseMapDiff
+
+	for name, newCourse := range new {
+		oldCourse, exists := old[name]
+		if !exists {
+			diff.AddedCourses = append(diff.AddedCourses, name)
+			continue
+		}
+
+		if courseDiff := DiffCourses(*oldCourse, *newCourse); !courseDiff.IsEmpty() {
+			diff.ChangedCourses = append(diff.ChangedCourses, courseDiff)
+		}
+	}
+
+	for name := range old {
+		if _, exists := new[name]; !exists {
+			diff.RemovedCourses = append(diff.RemovedCourses, name)
+		}
+	}
+
+	sort.Strings(diff.AddedCourses)
+	sort.Strings(diff.RemovedCourses)
+	sort.Slice(diff.ChangedCourses, func(i, j int) bool {
+		return diff.ChangedCourses[i].Name < diff.ChangedCourses[j].Name
+	})
+
+	return diff
+}
@@ -0,0 +1,84 @@
+package courseapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCourseMaps_NoChanges_Empty(t *testing.T) {
+	old := CourseMap{"CS101": newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})}
+	new := CourseMap{"CS101": newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})}
+
+	diff := DiffCourseMaps(old, new)
+
+	assert.True(t, diff.IsEmpty())
+	assert.Equal(t, "No changes.", diff.String())
+}
+
+func TestDiffCourseMaps_AddedAndRemovedCourses_Success(t *testing.T) {
+	old := CourseMap{"CS101": newTestCourse("CS101"), "ART100": newTestCourse("ART100")}
+	new := CourseMap{"CS101": newTestCourse("CS101"), "MATH200": newTestCourse("MATH200"), "BIO110": newTestCourse("BIO110")}
+
+	diff := DiffCourseMaps(old, new)
+
+	assert.Equal(t, []string{"BIO110", "MATH200"}, diff.AddedCourses)
+	assert.Equal(t, []string{"ART100"}, diff.RemovedCourses)
+	assert.Equal(t, 0, len(diff.ChangedCourses))
+}
+
+func TestDiffCourseMaps_AssignmentChanges_Success(t *testing.T) {
+	old := CourseMap{"CS101": newTestCourse("CS101",
+		[2]string{"Lab 1", "02/02/25"},
+		[2]string{"Lab 2", "02/09/25"},
+	)}
+	new := CourseMap{"CS101": newTestCourse("CS101",
+		[2]string{"Lab 2", "02/16/25"},
+		[2]string{"Lab 3", "02/23/25"},
+	)}
+
+	diff := DiffCourseMaps(old, new)
+
+	assert.Equal(t, 1, len(diff.ChangedCourses))
+	course := diff.ChangedCourses[0]
+	assert.False(t, course.InfoChanged)
+	assert.Equal(t, "Lab 3", course.AddedAssignments[0].Name)
+	assert.Equal(t, "Lab 1", course.RemovedAssignments[0].Name)
+	assert.Equal(t, "Lab 2", course.ChangedAssignments[0].New.Name)
+
+	expected := "~ Course `CS101` changed\n" +
+		"    + Assignment `Lab 3` (due 02/23/25) added\n" +
+		"    - Assignment `Lab 1` (due 02/02/25) removed\n" +
+		"    ~ Assignment `Lab 2` changed (due 02/09/25 -> 02/16/25)"
+	assert.Equal(t, expected, diff.String())
+}
+
+func TestDiffCourseMaps_DuplicateAssignmentNames_Success(t *testing.T) {
+	old := CourseMap{"CS101": newTestCourse("CS101",
+		[2]string{"Quiz", "02/02/25"},
+		[2]string{"Quiz", "02/09/25"},
+	)}
+	new := CourseMap{"CS101": newTestCourse("CS101",
+		[2]string{"Quiz", "02/02/25"},
+	)}
+
+	diff := DiffCourseMaps(old, new)
+
+	course := diff.ChangedCourses[0]
+	assert.Equal(t, 0, len(course.AddedAssignments))
+	assert.Equal(t, 0, len(course.ChangedAssignments))
+	assert.Equal(t, 1, len(course.RemovedAssignments))
+	assert.Equal(t, "02/09/25", getDateFromTime(course.RemovedAssignments[0].DueAt))
+}
+
+func TestDiffCourses_InfoChanged_Success(t *testing.T) {
+	info := "Intro to CS"
+	old := newTestCourse("CS101")
+	new := newTestCourse("CS101")
+	new.Course_Info = &info
+
+	diff := DiffCourses(*old, *new)
+
+	assert.True(t, diff.InfoChanged)
+	assert.False(t, diff.IsEmpty())
+}
@@ -0,0 +1,198 @@
+package courseapi
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// The digest covers this many days, starting today
+	DefaultDigestDays = 7
+
+	digestTextTemplate = `Deadlines for {{shortDate .Start}} - {{shortDate .Last}}
+{{range .Days}}
+{{longDate .Date}}
+{{- range .Items}}
+  [{{if .Complete}}x{{else}} {{end}}] {{.Course}}: {{.Assignment.Name}}{{if .Assignment.Category}} ({{.Assignment.Category}}){{end}}
+{{- end}}
+{{end}}{{if not .Days}}
+Nothing is due this week.
+{{end}}
+{{- if .Open}}
+{{.Open}} of {{.Total}} assignment(s) still open.
+{{end}}`
+
+	digestHTMLTemplate = `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif">
+<h2>Deadlines for {{shortDate .Start}} - {{shortDate .Last}}</h2>
+{{range .Days}}<h3>{{longDate .Date}}</h3>
+<ul>
+{{range .Items}}<li>{{if .Complete}}<s>{{end}}<b>{{.Course}}</b>: {{.Assignment.Name}}{{if .Assignment.Category}} ({{.Assignment.Category}}){{end}}{{if .Complete}}</s> &#10003;{{end}}</li>
+{{end}}</ul>
+{{else}}<p>Nothing is due this week.</p>
+{{end}}{{if .Open}}<p>{{.Open}} of {{.Total}} assignment(s) still open.</p>
+{{end}}</body>
+</html>
+`
+)
+
+// Dates follow DateFormat, which is read when the digest is rendered since it's configurable
+var digestFuncs = map[string]any{
+	"shortDate": func(t time.Time) string { return t.Format("Mon " + DateFormat) },
+	"longDate":  func(t time.Time) string { return t.Format("Monday " + DateFormat) },
+}
+
+var (
+	digestText = template.Must(template.New("digest").Funcs(digestFuncs).Parse(digestTextTemplate))
+	digestHTML = htmltemplate.Must(htmltemplate.New("digest").Funcs(digestFuncs).Parse(digestHTMLTemplate))
+)
+
+// An assignment due within a digest
+type DigestItem struct {
+	Course     string
+	Assignment AssignmentItem
+	// Graded, or with its whole checklist checked off
+	Complete bool
+}
+
+// The assignments due on one day of a digest, by course
+type DigestDay struct {
+	Date  time.Time
+	Items []DigestItem
+}
+
+// A summary of deadlines over the coming days, rendered as plain text and HTML for email
+type Digest struct {
+	Start time.Time
+	// The final day covered
+	Last  time.Time
+	Days  []DigestDay
+	Total int
+	Open  int
+}
+
+// Collects every assignment across cm due in the given number of days starting on from.
+// Days with nothing due are left out.
+func NewDigest(cm CourseMap, from time.Time, days int) Digest {
+	start := filterToday(from)
+	end := start.AddDate(0, 0, days)
+	d := Digest{Start: start, Last: end.AddDate(0, 0, -1)}
+
+	byDay := make(map[time.Time][]DigestItem)
+	for name, course := range cm {
+		for _, a := range course.Assignments {
+			if a.DueAt.Before(start) || !a.DueAt.Before(end) {
+				continue
+			}
+
+			day := filterToday(a.DueAt)
+			item := DigestItem{Course: name, Assignment: a, Complete: a.IsComplete()}
+			byDay[day] = append(byDay[day], item)
+
+			d.Total++
+			if !item.Complete {
+				d.Open++
+			}
+		}
+	}
+
+	for date, items := range byDay {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Course != items[j].Course {
+				return items[i].Course < items[j].Course
+			}
+			return items[i].Assignment.Name < items[j].Assignment.Name
+		})
+
+		d.Days = append(d.Days, DigestDay{Date: date, Items: items})
+	}
+
+	sort.Slice(d.Days, func(i, j int) bool {
+		return d.Days[i].Date.Before(d.Days[j].Date)
+	})
+
+	return d
+}
+
+func (d Digest) Subject() string {
+	return fmt.Sprintf("Deadlines for the week of %s", d.Start.Format(DateFormat))
+}
+
+func (d Digest) Text() (string, error) {
+	var b strings.Builder
+	err := digestText.Execute(&b, d)
+	return b.String(), err
+}
+
+func (d Digest) HTML() (string, error) {
+	var b strings.Builder
+	err := digestHTML.Execute(&b, d)
+	return b.String(), err
+}
+
+// Builds a multipart email with both the text and HTML versions of the digest, dated now
+func (d Digest) Message(from string, to []string, now time.Time) ([]byte, error) {
+	text, err := d.Text()
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := d.HTML()
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := [][2]string{
+		{"From", from},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", d.Subject())},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", header[0], header[1])
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,73 @@
+package courseapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDigest_Success(t *testing.T) {
+	cm := newTestTerm()
+	cm["CS101"].RecordScore(0, 10)
+	cm["CS101"].Assignments.AddAssignment("Quiz", "02/04/25")
+	cm["MATH200"].Assignments[0].Name = "Problem Set <1>"
+
+	d := NewDigest(cm, time.Date(2025, 2, 2, 9, 0, 0, 0, time.UTC), DefaultDigestDays)
+
+	assert.Equal(t, "02/08/25", d.Last.Format(DateFormat))
+	assert.Equal(t, 2, len(d.Days))
+	assert.Equal(t, 3, d.Total)
+	assert.Equal(t, 2, d.Open)
+	assert.Equal(t, "CS101", d.Days[1].Items[0].Course)
+	assert.Equal(t, "Deadlines for the week of 02/02/25", d.Subject())
+
+	text, err := d.Text()
+	assert.NoError(t, err)
+	assert.Equal(t, `Deadlines for Sun 02/02/25 - Sat 02/08/25
+
+Sunday 02/02/25
+  [x] CS101: Lab 1 (Labs)
+
+Tuesday 02/04/25
+  [ ] CS101: Quiz
+  [ ] MATH200: Problem Set <1>
+
+2 of 3 assignment(s) still open.
+`, text)
+
+	html, err := d.HTML()
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<li><s><b>CS101</b>: Lab 1 (Labs)</s> &#10003;</li>")
+	assert.Contains(t, html, "<li><b>MATH200</b>: Problem Set &lt;1&gt;</li>")
+}
+
+func TestNewDigest_NothingDue_Success(t *testing.T) {
+	d := NewDigest(newTestTerm(), time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC), DefaultDigestDays)
+
+	text, _ := d.Text()
+	assert.Equal(t, "Deadlines for Sun 03/16/25 - Sat 03/22/25\n\nNothing is due this week.\n", text)
+
+	html, _ := d.HTML()
+	assert.Contains(t, html, "<p>Nothing is due this week.</p>")
+}
+
+func TestDigest_Message_Success(t *testing.T) {
+	cm := newTestTerm()
+	cm["MATH200"].Assignments[0].Name = "Problem Set <1>"
+
+	d := NewDigest(cm, time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC), DefaultDigestDays)
+	now := time.Date(2025, 2, 3, 7, 0, 0, 0, time.UTC)
+
+	msg, err := d.Message("bot@example.com", []string{"a@example.com", "b@example.com"}, now)
+	assert.NoError(t, err)
+
+	s := string(msg)
+	assert.True(t, strings.HasPrefix(s, "From: bot@example.com\r\nTo: a@example.com, b@example.com\r\nSubject: Deadlines for the week of 02/02/25\r\n"))
+	assert.Contains(t, s, "Date: Mon, 03 Feb 2025 07:00:00 +0000\r\n")
+	assert.Contains(t, s, "Content-Type: multipart/alternative; boundary=")
+	assert.Contains(t, s, "Content-Type: text/plain; charset=utf-8")
+	assert.Contains(t, s, "Content-Type: text/html; charset=utf-8")
+	assert.Contains(t, s, "MATH200: Problem Set <1>")
+}
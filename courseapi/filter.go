@@ -0,0 +1,321 @@
+package courseapi
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	UnterminatedQuoteErrMsg = "missing closing quote"
+	UnknownFilterKeyErrMsg  = "unknown filter (use course:, tag:, category:, status:, text: or due<, due<=, due=, due>=, due>)"
+	EmptyFilterValueErrMsg  = "filter is missing a value"
+	InvalidGlobErrMsg       = "course pattern is malformed"
+	InvalidStatusErrMsg     = "status must be open, overdue or graded"
+	InvalidDueErrMsg        = "due must be compared to a date, today, or a number of days or weeks from today such as 7d or 2w"
+)
+
+// A filter expression that failed to parse, pointing at the offending term
+type FilterParseError struct {
+	// 1-based position of the term within the expression
+	Column int
+	Term   string
+	Msg    string
+}
+
+func (e *FilterParseError) Error() string {
+	return fmt.Sprintf("column %d, `%s`: %s", e.Column, e.Term, e.Msg)
+}
+
+type filterTerm struct {
+	column int
+	text   string
+}
+
+// Splits an expression on whitespace, keeping double-quoted values such as text:"final exam" together
+func tokenizeFilter(expr string) ([]filterTerm, error) {
+	var terms []filterTerm
+	var current strings.Builder
+	start, inQuote, quoteStart := -1, false, 0
+
+	flush := func() {
+		if start >= 0 {
+			terms = append(terms, filterTerm{column: start + 1, text: current.String()})
+		}
+		current.Reset()
+		start = -1
+	}
+
+	for i, r := range expr {
+		switch {
+		case r == '"':
+			if start < 0 {
+				start = i
+			}
+			if !inQuote {
+				quoteStart = i
+			}
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			if start < 0 {
+				start = i
+			}
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuote {
+		return nil, &FilterParseError{Column: quoteStart + 1, Term: expr[quoteStart:], Msg: UnterminatedQuoteErrMsg}
+	}
+	flush()
+
+	return terms, nil
+}
+
+type assignmentPredicate func(course *CourseItem, a AssignmentItem, today time.Time) bool
+
+// A parsed filter expression. Every term must match, so terms narrow the results down.
+// The zero Filter matches everything.
+type Filter struct {
+	predicates []assignmentPredicate
+}
+
+// Parses a filter expression made of space-separated terms:
+//
+//	course:<pattern>   course name, with * and ? wildcards (e.g. CS*)
+//	tag:<tag>          assignments with the tag
+//	category:<name>    assignments in the grading category
+//	status:<status>    open (ungraded, not yet due), overdue (ungraded, past due) or graded
+//	due<op><when>      op is <, <=, =, >= or >; when is a date in DateFormat, today, or Nd / Nw from today
+//	text:<words>       name or info contains the words; bare words do the same
+//
+// Values with spaces can be quoted, e.g. text:"final exam". Matching is case-insensitive.
+func ParseFilter(expr string) (Filter, error) {
+	terms, err := tokenizeFilter(expr)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	var f Filter
+	for _, term := range terms {
+		predicate, msg := parseFilterTerm(term.text)
+		if msg != "" {
+			return Filter{}, &FilterParseError{Column: term.column, Term: term.text, Msg: msg}
+		}
+
+		f.predicates = append(f.predicates, predicate)
+	}
+
+	return f, nil
+}
+
+// Returns the term's predicate, or a message saying why it can't be parsed
+func parseFilterTerm(term string) (assignmentPredicate, string) {
+	if rest, found := strings.CutPrefix(strings.ToLower(term), "due"); found && rest != "" && strings.ContainsRune("<>=", rune(rest[0])) {
+		return parseDueTerm(term[len("due"):])
+	}
+
+	key, value, found := strings.Cut(term, ":")
+	if !found {
+		return textPredicate(term), ""
+	}
+
+	if value == "" {
+		return nil, EmptyFilterValueErrMsg
+	}
+
+	switch strings.ToLower(key) {
+	case "course":
+		pattern := strings.ToLower(value)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, InvalidGlobErrMsg
+		}
+
+		return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+			matched, _ := path.Match(pattern, strings.ToLower(course.Name))
+			return matched
+		}, ""
+	case "tag":
+		return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+			return a.HasTag(value)
+		}, ""
+	case "category":
+		return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+			return a.Category != nil && strings.EqualFold(*a.Category, value)
+		}, ""
+	case "status":
+		return parseStatusTerm(strings.ToLower(value))
+	case "text":
+		return textPredicate(value), ""
+	default:
+		return nil, UnknownFilterKeyErrMsg
+	}
+}
+
+func textPredicate(text string) assignmentPredicate {
+	text = strings.ToLower(text)
+
+	return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+		if strings.Contains(strings.ToLower(a.Name), text) {
+			return true
+		}
+
+		return a.Info != nil && strings.Contains(strings.ToLower(*a.Info), text)
+	}
+}
+
+func parseStatusTerm(status string) (assignmentPredicate, string) {
+	switch status {
+	case "open":
+		return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+			return !a.IsGraded() && !a.DueAt.Before(today)
+		}, ""
+	case "overdue":
+		return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+			return !a.IsGraded() && a.DueAt.Before(today)
+		}, ""
+	case "graded":
+		return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+			return a.IsGraded()
+		}, ""
+	default:
+		return nil, InvalidStatusErrMsg
+	}
+}
+
+// InvalidDueErrMsg along with how dates are written, since that's configurable
+func invalidDueMsg() string {
+	return fmt.Sprintf("%s (dates as %s)", InvalidDueErrMsg, DatePattern())
+}
+
+// Parses the comparison after `due`, e.g. `<7d` or `>=03/01/25`
+func parseDueTerm(comparison string) (assignmentPredicate, string) {
+	op := comparison[:1]
+	if len(comparison) > 1 && comparison[1] == '=' {
+		op = comparison[:2]
+	}
+
+	when := strings.ToLower(comparison[len(op):])
+	if when == "" {
+		return nil, EmptyFilterValueErrMsg
+	}
+
+	// The date being compared against is resolved when matching, so relative dates follow the clock
+	var dateFor func(today time.Time) time.Time
+	if when == "today" {
+		dateFor = func(today time.Time) time.Time { return today }
+	} else if date, err := time.Parse(DateFormat, when); err == nil {
+		dateFor = func(today time.Time) time.Time { return date }
+	} else if n, err := strconv.Atoi(when[:len(when)-1]); err == nil && strings.ContainsRune("dw", rune(when[len(when)-1])) {
+		days := n
+		if when[len(when)-1] == 'w' {
+			days *= 7
+		}
+		dateFor = func(today time.Time) time.Time { return today.AddDate(0, 0, days) }
+	} else {
+		return nil, invalidDueMsg()
+	}
+
+	var compare func(due time.Time, date time.Time) bool
+	switch op {
+	case "<":
+		compare = func(due, date time.Time) bool { return due.Before(date) }
+	case "<=":
+		compare = func(due, date time.Time) bool { return !due.After(date) }
+	case "=":
+		compare = func(due, date time.Time) bool { return due.Equal(date) }
+	case ">=":
+		compare = func(due, date time.Time) bool { return !due.Before(date) }
+	case ">":
+		compare = func(due, date time.Time) bool { return due.After(date) }
+	default:
+		return nil, invalidDueMsg()
+	}
+
+	return func(course *CourseItem, a AssignmentItem, today time.Time) bool {
+		return compare(a.DueAt, dateFor(today))
+	}, ""
+}
+
+// Due dates are calendar days, so now is truncated to its day for comparisons
+func filterToday(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (f Filter) Match(course *CourseItem, a AssignmentItem, now time.Time) bool {
+	today := filterToday(now)
+	for _, predicate := range f.predicates {
+		if !predicate(course, a, today) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// An assignment found by a filter, with its 1-based number within its course
+type AssignmentMatch struct {
+	Course     string
+	Number     int
+	Assignment AssignmentItem
+}
+
+type AssignmentMatches []AssignmentMatch
+
+// Finds every assignment across cm that matches f, ordered by due date then course
+func (f Filter) Find(cm CourseMap, now time.Time) AssignmentMatches {
+	var matches AssignmentMatches
+	for _, course := range cm {
+		matches = append(matches, f.FindInCourse(course, now)...)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if !a.Assignment.DueAt.Equal(b.Assignment.DueAt) {
+			return a.Assignment.DueAt.Before(b.Assignment.DueAt)
+		}
+		if a.Course != b.Course {
+			return a.Course < b.Course
+		}
+		return a.Number < b.Number
+	})
+
+	return matches
+}
+
+// Finds the course's assignments that match f, in list order
+func (f Filter) FindInCourse(course *CourseItem, now time.Time) AssignmentMatches {
+	var matches AssignmentMatches
+	for i, a := range course.Assignments {
+		if f.Match(course, a, now) {
+			matches = append(matches, AssignmentMatch{Course: course.Name, Number: i + 1, Assignment: a})
+		}
+	}
+
+	return matches
+}
+
+// Lists matches numbered as in list-assignments, prefixed with their course when withCourse is set
+func (m AssignmentMatches) String(withCourse bool) string {
+	if len(m) == 0 {
+		return "No matching assignments."
+	}
+
+	result := ""
+	for _, match := range m {
+		prefix := ""
+		if withCourse {
+			prefix = match.Course + " "
+		}
+
+		result += fmt.Sprintf("%s%d. %s\n\n", prefix, match.Number, match.Assignment.String())
+	}
+
+	return strings.TrimSuffix(result, "\n")
+}
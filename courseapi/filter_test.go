@@ -0,0 +1,84 @@
+package courseapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Wednesday, 02/05/25
+var filterTestNow = time.Date(2025, 2, 5, 15, 30, 0, 0, time.UTC)
+
+func findNames(t *testing.T, expr string) []string {
+	f, err := ParseFilter(expr)
+	assert.NoError(t, err)
+
+	cm := newTestTerm()
+	cm["CS101"].RecordScore(0, 9)
+
+	var names []string
+	for _, match := range f.Find(cm, filterTestNow) {
+		names = append(names, match.Course+" "+match.Assignment.Name)
+	}
+
+	return names
+}
+
+func TestParseFilter_Empty_MatchesEverything_Success(t *testing.T) {
+	assert.Equal(t, 10, len(findNames(t, "")))
+}
+
+func TestParseFilter_CourseAndTag_Success(t *testing.T) {
+	assert.Equal(t, []string{"CS101 Midterm", "CS101 Final"}, findNames(t, "course:cs* tag:EXAM"))
+	assert.Equal(t, []string{"MATH200 Midterm"}, findNames(t, "course:MATH??? tag:exam"))
+}
+
+func TestParseFilter_Due_Success(t *testing.T) {
+	assert.Equal(t, []string{"CS101 Lab 1", "MATH200 Problem Set 1"}, findNames(t, "due<today"))
+	assert.Equal(t, []string{"CS101 Lab 2", "MATH200 Midterm"}, findNames(t, "due>today due<=7d"))
+	assert.Equal(t, []string{"CS101 Final"}, findNames(t, "due>=05/01/25"))
+	assert.Equal(t, []string{"BIO110 Tree of Life Essay", "CS101 Midterm", "CS201 Red-Black Trees", "CS201 Heaps", "CS201 Hash Tables", "CS101 Final"}, findNames(t, "due>2w"))
+	assert.Equal(t, []string{"CS101 Lab 1"}, findNames(t, "due=-3d"))
+}
+
+func TestParseFilter_Status_Success(t *testing.T) {
+	assert.Equal(t, []string{"CS101 Lab 1"}, findNames(t, "status:graded"))
+	assert.Equal(t, []string{"MATH200 Problem Set 1"}, findNames(t, "status:overdue"))
+	assert.Equal(t, 8, len(findNames(t, "status:open")))
+}
+
+func TestParseFilter_Text_Success(t *testing.T) {
+	assert.Equal(t, []string{"MATH200 Midterm"}, findNames(t, `text:"chapters 1"`))
+	assert.Equal(t, []string{"MATH200 Midterm", "CS101 Midterm"}, findNames(t, "MIDTERM"))
+	assert.Equal(t, []string{"CS101 Lab 1"}, findNames(t, "category:labs status:graded"))
+}
+
+func TestParseFilter_Invalid_Failure(t *testing.T) {
+	tests := map[string]string{
+		"tag:exam colour:red":  "column 10, `colour:red`: " + UnknownFilterKeyErrMsg,
+		"status:done":          "column 1, `status:done`: " + InvalidStatusErrMsg,
+		"due<soon":             "column 1, `due<soon`: " + invalidDueMsg(),
+		"due<":                 "column 1, `due<`: " + EmptyFilterValueErrMsg,
+		"tag:":                 "column 1, `tag:`: " + EmptyFilterValueErrMsg,
+		"course:[":             "column 1, `course:[`: " + InvalidGlobErrMsg,
+		`tag:exam text:"final`: "column 15, `\"final`: " + UnterminatedQuoteErrMsg,
+	}
+
+	for expr, expected := range tests {
+		_, err := ParseFilter(expr)
+		assert.EqualError(t, err, expected, expr)
+
+		var parseErr *FilterParseError
+		assert.ErrorAs(t, err, &parseErr)
+	}
+}
+
+func TestAssignmentMatches_String_KeepsNumbers_Success(t *testing.T) {
+	f, _ := ParseFilter("tag:exam")
+	matches := f.FindInCourse(newTestTerm()["MATH200"], filterTestNow)
+
+	assert.Equal(t, "2. Midterm\nCovers chapters 1-4\nDue: 02/12/25\nTags: exam\n", matches.String(false))
+	assert.Equal(t, "MATH200 2. Midterm\nCovers chapters 1-4\nDue: 02/12/25\nTags: exam\n", matches.String(true))
+	assert.Equal(t, "No matching assignments.", AssignmentMatches{}.String(true))
+}
@@ -0,0 +1,53 @@
+package courseapi
+
+// A course with assignments given as {name, due date} pairs, in DateFormat
+func newTestCourse(name string, assignments ...[2]string) *CourseItem {
+	course := &CourseItem{Name: name, Assignments: AssignmentList{}}
+	for _, a := range assignments {
+		course.Assignments.AddAssignment(a[0], a[1])
+	}
+
+	return course
+}
+
+// A term shared by the feature tests, which add whatever else they need (scores, effort,
+// subtasks, prerequisites) themselves:
+//
+//	CS101   Lab 1 02/02/25, Lab 2 02/09/25 (Labs, 10 points); Midterm 03/01/25, Final 05/01/25
+//	        (Exams, 100 points, tagged exam); Labs are weighted 40 and Exams 60
+//	MATH200 Problem Set 1 02/04/25; Midterm 02/12/25 (with info, tagged exam)
+//	CS201   Red-Black Trees 03/01/25, Heaps 03/08/25 (with info), Hash Tables 03/15/25; with info
+//	BIO110  Tree of Life Essay 02/20/25
+func newTestTerm() CourseMap {
+	cs := newTestCourse("CS101",
+		[2]string{"Lab 1", "02/02/25"},
+		[2]string{"Lab 2", "02/09/25"},
+		[2]string{"Midterm", "03/01/25"},
+		[2]string{"Final", "05/01/25"})
+	cs.SetCategory("Labs", 40)
+	cs.SetCategory("Exams", 60)
+	cs.SetAssignmentPoints(0, 10, "Labs")
+	cs.SetAssignmentPoints(1, 10, "Labs")
+	cs.SetAssignmentPoints(2, 100, "Exams")
+	cs.SetAssignmentPoints(3, 100, "Exams")
+	cs.AddTags(2, "exam")
+	cs.AddTags(3, "exam")
+
+	math := newTestCourse("MATH200", [2]string{"Problem Set 1", "02/04/25"}, [2]string{"Midterm", "02/12/25"})
+	mathInfo := "Covers chapters 1-4"
+	math.Assignments[1].Info = &mathInfo
+	math.AddTags(1, "exam")
+
+	structures := newTestCourse("CS201",
+		[2]string{"Red-Black Trees", "03/01/25"},
+		[2]string{"Heaps", "03/08/25"},
+		[2]string{"Hash Tables", "03/15/25"})
+	structuresInfo := "Data structures: trees, heaps and hashing"
+	structures.Course_Info = &structuresInfo
+	heapInfo := "Implement a binary heap; red team reviews"
+	structures.Assignments[1].Info = &heapInfo
+
+	bio := newTestCourse("BIO110", [2]string{"Tree of Life Essay", "02/20/25"})
+
+	return CourseMap{"CS101": cs, "MATH200": math, "CS201": structures, "BIO110": bio}
+}
@@ -0,0 +1,262 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	InvalidWeightErrMsg          = "category weight must be a number greater than 0"
+	InvalidPointsErrMsg          = "points possible must be a number greater than 0"
+	InvalidScoreErrMsg           = "points earned must be a number that isn't negative"
+	UnknownCategoryErrMsg        = "grading category doesn't exist"
+	CategoryInUseErrMsg          = "grading category is still used by assignments"
+	PointsNotSetErrMsg           = "assignment has no points possible set"
+	NoGradedAssignmentsErrMsg    = "no graded assignments yet"
+	UncategorizedAssignmentLabel = "Uncategorized"
+)
+
+// Whether v is an actual number, rather than NaN or an infinity, which would poison every grade
+// computed from it
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// A weighted group of assignments, e.g. "Exams" worth 40
+type GradeCategory struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+// Adds a grading category or changes the weight of an existing one
+func (c *CourseItem) SetCategory(name string, weight float64) error {
+	if !isFinite(weight) || weight <= 0 {
+		return errors.New(InvalidWeightErrMsg)
+	}
+
+	for i := range c.Categories {
+		if c.Categories[i].Name == name {
+			c.Categories[i].Weight = weight
+			return nil
+		}
+	}
+
+	c.Categories = append(c.Categories, GradeCategory{Name: name, Weight: weight})
+	return nil
+}
+
+func (c *CourseItem) RemoveCategory(name string) error {
+	for _, a := range c.Assignments {
+		if a.Category != nil && *a.Category == name {
+			return errors.New(CategoryInUseErrMsg)
+		}
+	}
+
+	for i := range c.Categories {
+		if c.Categories[i].Name == name {
+			c.Categories = append(c.Categories[:i], c.Categories[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(UnknownCategoryErrMsg)
+}
+
+func (c CourseItem) category(name string) (GradeCategory, bool) {
+	for _, category := range c.Categories {
+		if category.Name == name {
+			return category, true
+		}
+	}
+
+	return GradeCategory{}, false
+}
+
+// Sets how many points an assignment is worth and, optionally, which grading category it counts toward
+func (c *CourseItem) SetAssignmentPoints(index int, possible float64, category ...string) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	if !isFinite(possible) || possible <= 0 {
+		return errors.New(InvalidPointsErrMsg)
+	}
+
+	if len(category) > 1 {
+		return errors.New(TooManyParamsErrMsg)
+	}
+
+	assignment := &c.Assignments[index]
+	if len(category) == 1 {
+		if _, exists := c.category(category[0]); !exists {
+			return errors.New(UnknownCategoryErrMsg)
+		}
+
+		categoryName := category[0]
+		assignment.Category = &categoryName
+	}
+
+	assignment.PointsPossible = &possible
+	return nil
+}
+
+// Records the points earned on an assignment, which must already have its points possible set.
+// Scores above the points possible count as extra credit.
+func (c *CourseItem) RecordScore(index int, earned float64) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	if !isFinite(earned) || earned < 0 {
+		return errors.New(InvalidScoreErrMsg)
+	}
+
+	assignment := &c.Assignments[index]
+	if assignment.PointsPossible == nil {
+		return errors.New(PointsNotSetErrMsg)
+	}
+
+	assignment.PointsEarned = &earned
+	return nil
+}
+
+func (c *CourseItem) ClearScore(index int) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	c.Assignments[index].PointsEarned = nil
+	return nil
+}
+
+func (a AssignmentItem) IsGraded() bool {
+	return a.PointsPossible != nil && a.PointsEarned != nil
+}
+
+func (a AssignmentItem) gradeString() string {
+	result := ""
+	if a.Category != nil {
+		result += fmt.Sprintf("\nCategory: %s", *a.Category)
+	}
+	if a.IsGraded() {
+		result += fmt.Sprintf("\nScore: %g/%g", *a.PointsEarned, *a.PointsPossible)
+	} else if a.PointsPossible != nil {
+		result += fmt.Sprintf("\nPoints: %g", *a.PointsPossible)
+	}
+
+	return result
+}
+
+type CategoryGrade struct {
+	Name     string
+	Weight   float64
+	Earned   float64
+	Possible float64
+	Graded   int
+}
+
+// Percentage of points earned, or 0 if nothing in the category is graded yet
+func (g CategoryGrade) Percent() float64 {
+	if g.Possible == 0 {
+		return 0
+	}
+
+	return g.Earned / g.Possible * 100
+}
+
+type CourseGrade struct {
+	Percent    float64
+	Weighted   bool
+	Categories []CategoryGrade
+}
+
+func (g CourseGrade) String() string {
+	result := fmt.Sprintf("Current grade: %.2f%%\n", g.Percent)
+
+	for _, category := range g.Categories {
+		if g.Weighted {
+			result += fmt.Sprintf("  %s (weight %g): ", category.Name, category.Weight)
+		} else {
+			result += fmt.Sprintf("  %s: ", category.Name)
+		}
+
+		if category.Graded == 0 {
+			result += "no graded assignments\n"
+		} else {
+			result += fmt.Sprintf("%g/%g (%.2f%%)\n", category.Earned, category.Possible, category.Percent())
+		}
+	}
+
+	return strings.TrimSuffix(result, "\n")
+}
+
+// Computes the current grade from graded assignments only. With grading categories, each category's
+// percentage counts by its weight, re-normalized over the categories that have grades so far;
+// assignments outside any category are left out. Without categories, it's simply total points.
+func (c CourseItem) Grade() (CourseGrade, error) {
+	grade := CourseGrade{Weighted: len(c.Categories) > 0}
+
+	index := make(map[string]int)
+	if grade.Weighted {
+		for _, category := range c.Categories {
+			index[category.Name] = len(grade.Categories)
+			grade.Categories = append(grade.Categories, CategoryGrade{Name: category.Name, Weight: category.Weight})
+		}
+	}
+
+	graded := 0
+	for _, a := range c.Assignments {
+		if !a.IsGraded() {
+			continue
+		}
+
+		name := UncategorizedAssignmentLabel
+		if a.Category != nil {
+			name = *a.Category
+		}
+
+		i, exists := index[name]
+		if !exists {
+			if grade.Weighted {
+				continue
+			}
+
+			index[name] = len(grade.Categories)
+			i = index[name]
+			grade.Categories = append(grade.Categories, CategoryGrade{Name: name})
+		}
+
+		grade.Categories[i].Earned += *a.PointsEarned
+		grade.Categories[i].Possible += *a.PointsPossible
+		grade.Categories[i].Graded++
+		graded++
+	}
+
+	if graded == 0 {
+		return grade, errors.New(NoGradedAssignmentsErrMsg)
+	}
+
+	if !grade.Weighted {
+		earned, possible := 0.0, 0.0
+		for _, category := range grade.Categories {
+			earned += category.Earned
+			possible += category.Possible
+		}
+
+		grade.Percent = earned / possible * 100
+		return grade, nil
+	}
+
+	weighted, totalWeight := 0.0, 0.0
+	for _, category := range grade.Categories {
+		if category.Graded > 0 {
+			weighted += category.Percent() * category.Weight
+			totalWeight += category.Weight
+		}
+	}
+
+	grade.Percent = weighted / totalWeight
+	return grade, nil
+}
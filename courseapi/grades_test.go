@@ -0,0 +1,166 @@
+package courseapi
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCourseItem_SetCategory_UpdatesExisting_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+
+	assert.NoError(t, course.SetCategory("Labs", 40))
+	assert.NoError(t, course.SetCategory("Labs", 30))
+
+	assert.Equal(t, []GradeCategory{{Name: "Labs", Weight: 30}}, course.Categories)
+}
+
+func TestCourseItem_SetCategory_InvalidWeight_Failure(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+
+	for _, weight := range []float64{0, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		err := course.SetCategory("Labs", weight)
+		assert.EqualError(t, err, InvalidWeightErrMsg, weight)
+	}
+	assert.Empty(t, course.Categories)
+}
+
+func TestCourseItem_RemoveCategory_InUse_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+
+	err := course.RemoveCategory("Labs")
+	assert.EqualError(t, err, CategoryInUseErrMsg)
+
+	err = course.RemoveCategory("Quizzes")
+	assert.EqualError(t, err, UnknownCategoryErrMsg)
+}
+
+func TestCourseItem_SetAssignmentPoints_UnknownCategory_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+
+	err := course.SetAssignmentPoints(0, 10, "Quizzes")
+	assert.EqualError(t, err, UnknownCategoryErrMsg)
+
+	err = course.SetAssignmentPoints(0, -5)
+	assert.EqualError(t, err, InvalidPointsErrMsg)
+
+	err = course.SetAssignmentPoints(9, 10)
+	assert.EqualError(t, err, InvalidSliceRemoveErrMsg)
+}
+
+func TestCourseItem_SetAssignmentPoints_NotFinite_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+
+	for _, possible := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		err := course.SetAssignmentPoints(0, possible)
+		assert.EqualError(t, err, InvalidPointsErrMsg, possible)
+	}
+	assert.Equal(t, 10.0, *course.Assignments[0].PointsPossible)
+}
+
+func TestCourseItem_RecordScore_PointsNotSet_Failure(t *testing.T) {
+	course := CourseItem{Name: "CS101", Assignments: AssignmentList{}}
+	course.Assignments.AddAssignment("Lab 1", "02/02/25")
+
+	err := course.RecordScore(0, 9)
+	assert.EqualError(t, err, PointsNotSetErrMsg)
+
+	course.SetAssignmentPoints(0, 10)
+	err = course.RecordScore(0, -1)
+	assert.EqualError(t, err, InvalidScoreErrMsg)
+}
+
+func TestCourseItem_RecordScore_NotFinite_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+
+	for _, earned := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		err := course.RecordScore(0, earned)
+		assert.EqualError(t, err, InvalidScoreErrMsg, earned)
+	}
+	assert.Nil(t, course.Assignments[0].PointsEarned)
+}
+
+func TestCourseItem_Grade_Weighted_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 9)  // Labs: 9/10
+	course.RecordScore(1, 7)  // Labs: 16/20 = 80%
+	course.RecordScore(2, 90) // Exams: 90/100 = 90%
+
+	grade, err := course.Grade()
+
+	assert.NoError(t, err)
+	assert.True(t, grade.Weighted)
+	assert.InDelta(t, 86.0, grade.Percent, 0.001) // 0.4*80 + 0.6*90
+	assert.Equal(t, 2, grade.Categories[0].Graded)
+	assert.InDelta(t, 80.0, grade.Categories[0].Percent(), 0.001)
+}
+
+func TestCourseItem_Grade_OnlySomeCategoriesGraded_Renormalized(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 8)
+
+	grade, err := course.Grade()
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 80.0, grade.Percent, 0.001)
+	assert.Equal(t, 0, grade.Categories[1].Graded)
+}
+
+func TestCourseItem_Grade_Unweighted_TotalPoints_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101", Assignments: AssignmentList{}}
+	course.Assignments.AddAssignment("HW 1", "02/02/25")
+	course.Assignments.AddAssignment("HW 2", "02/09/25")
+	course.SetAssignmentPoints(0, 10)
+	course.SetAssignmentPoints(1, 30)
+	course.RecordScore(0, 10)
+	course.RecordScore(1, 20)
+
+	grade, err := course.Grade()
+
+	assert.NoError(t, err)
+	assert.False(t, grade.Weighted)
+	assert.InDelta(t, 75.0, grade.Percent, 0.001)
+	assert.Equal(t, "Current grade: 75.00%\n  Uncategorized: 30/40 (75.00%)", grade.String())
+}
+
+func TestCourseItem_Grade_NothingGraded_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+
+	_, err := course.Grade()
+	assert.EqualError(t, err, NoGradedAssignmentsErrMsg)
+}
+
+func TestCourseItem_DeepCopy_GradingFields_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 9)
+
+	cpy := course.DeepCopy()
+	*cpy.Assignments[0].PointsEarned = 1
+	*cpy.Assignments[0].Category = "Exams"
+	cpy.Categories[0].Weight = 1
+
+	assert.Equal(t, 9.0, *course.Assignments[0].PointsEarned)
+	assert.Equal(t, "Labs", *course.Assignments[0].Category)
+	assert.Equal(t, 40.0, course.Categories[0].Weight)
+}
+
+func TestAssignmentItem_String_WithScore_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 9)
+
+	assert.Equal(t, "Lab 1\nDue: 02/02/25\nCategory: Labs\nScore: 9/10", course.Assignments[0].String())
+	assert.Equal(t, "Lab 2\nDue: 02/09/25\nCategory: Labs\nPoints: 10", course.Assignments[1].String())
+}
+
+func TestReplaceAssignment_KeepsGradingFields_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 9)
+
+	_, err := course.Assignments.ReplaceAssignment(0, "Lab 1 (redo)", "02/03/25")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Lab 1 (redo)", course.Assignments[0].Name)
+	assert.Equal(t, 9.0, *course.Assignments[0].PointsEarned)
+	assert.Equal(t, "Labs", *course.Assignments[0].Category)
+}
@@ -0,0 +1,209 @@
+package courseapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	EventAssignmentCreated = "assignment-created"
+	EventAssignmentUpdated = "assignment-updated"
+	EventAssignmentRemoved = "assignment-removed"
+	EventAssignmentDueSoon = "assignment-due-soon"
+	EventAssignmentOverdue = "assignment-overdue"
+
+	// Hooks named after this receive every event
+	AllEventsHookName = "all"
+
+	// Hooks taking longer than this are killed, unless configured otherwise
+	DefaultHookTimeout = 10 * time.Second
+
+	// Only this much of a failing hook's stderr is kept for its error
+	hookStderrLimit = 500
+)
+
+// Something that happened to an assignment, passed to hooks as JSON on stdin.
+// Previous is only set for updates, and the reminder fields only for due-soon and overdue events.
+type Event struct {
+	Type       string          `json:"type"`
+	At         time.Time       `json:"at"`
+	Course     string          `json:"course"`
+	Assignment AssignmentItem  `json:"assignment"`
+	Previous   *AssignmentItem `json:"previous,omitempty"`
+	Command    string          `json:"command,omitempty"`
+	Actor      string          `json:"actor,omitempty"`
+	Deadline   *time.Time      `json:"deadline,omitempty"`
+	Offset     string          `json:"offset,omitempty"`
+}
+
+// The assignment events for a course going from before to after, where a nil side means the
+// course was created or removed. Assignments are matched up by name, as in DiffCourses.
+func AssignmentChangeEvents(before *CourseItem, after *CourseItem, at time.Time) []Event {
+	var old, new CourseItem
+	if before != nil {
+		old = *before
+		new.Name = before.Name
+	}
+	if after != nil {
+		new = *after
+	}
+
+	diff := DiffCourses(old, new)
+	var events []Event
+	for _, a := range diff.AddedAssignments {
+		events = append(events, Event{Type: EventAssignmentCreated, At: at, Course: diff.Name, Assignment: a})
+	}
+	for _, change := range diff.ChangedAssignments {
+		previous := change.Old
+		events = append(events, Event{Type: EventAssignmentUpdated, At: at, Course: diff.Name, Assignment: change.New, Previous: &previous})
+	}
+	for _, a := range diff.RemovedAssignments {
+		events = append(events, Event{Type: EventAssignmentRemoved, At: at, Course: diff.Name, Assignment: a})
+	}
+
+	return events
+}
+
+// The due-soon or overdue event for a delivered reminder, if its assignment still exists in cm
+func ReminderEvent(cm CourseMap, r Reminder, at time.Time) (Event, bool) {
+	a, err := cm.resolve(AssignmentRef{Course: r.Course, Name: r.Assignment})
+	if err != nil {
+		return Event{}, false
+	}
+
+	event := Event{Type: EventAssignmentDueSoon, At: at, Course: r.Course, Assignment: a.DeepCopy(), Deadline: &r.Deadline}
+	if r.Overdue() {
+		event.Type = EventAssignmentOverdue
+	} else {
+		event.Offset = FormatReminderOffset(r.Offset)
+	}
+
+	return event, true
+}
+
+// A hook that failed to run, exited unsuccessfully or timed out
+type HookError struct {
+	Path   string
+	Event  string
+	Err    error
+	Stderr string
+}
+
+func (e *HookError) Error() string {
+	msg := fmt.Sprintf("hook `%s` failed on %s: %v", e.Path, e.Event, e.Err)
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+
+	return msg
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// Runs the executables in Dir for each event. A hook handles an event if its file name, without
+// anything after the first dot, is the event type or AllEventsHookName, e.g. `assignment-created`,
+// `assignment-overdue.sh` or `all.py`.
+type HookRunner struct {
+	Dir     string
+	Timeout time.Duration
+}
+
+// The hooks that handle eventType, in name order. A missing directory just means there are none.
+func (h HookRunner) Hooks(eventType string) ([]string, error) {
+	entries, err := os.ReadDir(h.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var hooks []string
+	for _, entry := range entries {
+		name, _, _ := strings.Cut(entry.Name(), ".")
+		if name != eventType && name != AllEventsHookName {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+
+		hooks = append(hooks, filepath.Join(h.Dir, entry.Name()))
+	}
+
+	sort.Strings(hooks)
+	return hooks, nil
+}
+
+// Runs every hook for the event one after another, with the event as JSON on stdin and its type in
+// GOSHEETS_EVENT. Each hook gets its own timeout, and one failing doesn't stop the rest.
+func (h HookRunner) Run(ctx context.Context, event Event) []error {
+	hooks, err := h.Hooks(event.Type)
+	if err != nil {
+		return []error{err}
+	}
+
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return []error{err}
+	}
+
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := runHook(ctx, hook, event.Type, payload, timeout); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func runHook(ctx context.Context, path string, eventType string, payload []byte, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "GOSHEETS_EVENT="+eventType)
+	// Don't wait on anything the hook left running in the background once it's killed
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	msg := strings.TrimSpace(stderr.String())
+	if len(msg) > hookStderrLimit {
+		msg = msg[:hookStderrLimit] + "..."
+	}
+
+	return &HookError{Path: path, Event: eventType, Err: err, Stderr: msg}
+}
@@ -0,0 +1,107 @@
+package courseapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestHook(t *testing.T, dir string, name string, script string) {
+	err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0755)
+	assert.NoError(t, err)
+}
+
+func TestAssignmentChangeEvents_Success(t *testing.T) {
+	before := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"}, [2]string{"Lab 2", "02/09/25"})
+	after := before.DeepCopy()
+	after.Assignments[0].DueAt = after.Assignments[0].DueAt.AddDate(0, 0, 1)
+	after.Assignments.RemoveAssignment(1)
+	after.Assignments.AddAssignment("Quiz", "02/10/25")
+
+	at := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	events := AssignmentChangeEvents(before, &after, at)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, EventAssignmentCreated, events[0].Type)
+	assert.Equal(t, "Quiz", events[0].Assignment.Name)
+	assert.Equal(t, EventAssignmentUpdated, events[1].Type)
+	assert.Equal(t, "02/02/25", events[1].Previous.DueAt.Format(DateFormat))
+	assert.Equal(t, EventAssignmentRemoved, events[2].Type)
+	assert.Equal(t, "CS101", events[2].Course)
+
+	removed := AssignmentChangeEvents(&after, nil, at)
+	assert.Equal(t, 2, len(removed))
+	assert.Equal(t, EventAssignmentRemoved, removed[0].Type)
+	assert.Equal(t, "CS101", removed[0].Course)
+}
+
+func TestReminderEvent_Success(t *testing.T) {
+	cm := CourseMap{"CS101": newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})}
+	defaults, _ := ParseReminderOffsets("1d")
+	now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	reminders := cm.Reminders(defaults, now, time.UTC)
+
+	event, ok := ReminderEvent(cm, reminders[0], now)
+	assert.True(t, ok)
+	assert.Equal(t, EventAssignmentDueSoon, event.Type)
+	assert.Equal(t, "1d", event.Offset)
+
+	event, _ = ReminderEvent(cm, reminders[1], now)
+	assert.Equal(t, EventAssignmentOverdue, event.Type)
+	assert.Equal(t, "", event.Offset)
+
+	cm["CS101"].Assignments[0].Name = "Lab 1 (late)"
+	_, ok = ReminderEvent(cm, reminders[0], now)
+	assert.False(t, ok)
+}
+
+func TestHookRunner_Run_Success(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	writeTestHook(t, dir, "assignment-created.sh", `cat > "`+out+`.created"`)
+	writeTestHook(t, dir, "all", `echo "$GOSHEETS_EVENT" >> "`+out+`.all"`)
+	writeTestHook(t, dir, "assignment-removed", "exit 1")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "assignment-created.txt"), []byte("not executable"), 0644))
+
+	hooks, err := HookRunner{Dir: dir}.Hooks(EventAssignmentCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "all"), filepath.Join(dir, "assignment-created.sh")}, hooks)
+
+	event := Event{Type: EventAssignmentCreated, Course: "CS101", Assignment: AssignmentItem{Name: "Lab 1"}}
+	assert.Empty(t, HookRunner{Dir: dir}.Run(context.Background(), event))
+
+	data, err := os.ReadFile(out + ".created")
+	assert.NoError(t, err)
+	var received Event
+	assert.NoError(t, json.Unmarshal(data, &received))
+	assert.Equal(t, "Lab 1", received.Assignment.Name)
+
+	data, _ = os.ReadFile(out + ".all")
+	assert.Equal(t, "assignment-created\n", string(data))
+
+	// A missing directory means no hooks
+	assert.Empty(t, HookRunner{Dir: filepath.Join(dir, "missing")}.Run(context.Background(), event))
+}
+
+func TestHookRunner_Run_Failure(t *testing.T) {
+	dir := t.TempDir()
+	writeTestHook(t, dir, "assignment-overdue.1", "echo broken >&2; exit 3")
+	writeTestHook(t, dir, "assignment-overdue.2", "sleep 5")
+	writeTestHook(t, dir, "assignment-overdue.3", "exit 0")
+
+	start := time.Now()
+	errs := HookRunner{Dir: dir, Timeout: 200 * time.Millisecond}.Run(context.Background(), Event{Type: EventAssignmentOverdue})
+	assert.Less(t, time.Since(start), 4*time.Second)
+	assert.Equal(t, 2, len(errs))
+
+	var hookErr *HookError
+	assert.True(t, errors.As(errs[0], &hookErr))
+	assert.Equal(t, "broken", hookErr.Stderr)
+	assert.Contains(t, errs[0].Error(), "assignment-overdue.1` failed on assignment-overdue: exit status 3: broken")
+	assert.Contains(t, errs[1].Error(), "timed out after 200ms")
+}
@@ -0,0 +1,63 @@
+package courseapi
+
+import (
+	"errors"
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+const (
+	MissingSMTPHostErrMsg   = "no SMTP host is configured"
+	MissingMailFromErrMsg   = "no sender address is configured"
+	MissingRecipientsErrMsg = "no recipients are configured"
+
+	DefaultSMTPPort = 587
+)
+
+// Where and how to send email. Username and Password are optional, for servers that don't require login.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (c SMTPConfig) Validate() error {
+	switch {
+	case c.Host == "":
+		return errors.New(MissingSMTPHostErrMsg)
+	case c.From == "":
+		return errors.New(MissingMailFromErrMsg)
+	case len(c.To) == 0:
+		return errors.New(MissingRecipientsErrMsg)
+	}
+
+	return nil
+}
+
+func (c SMTPConfig) addr() string {
+	port := c.Port
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+
+	return net.JoinHostPort(c.Host, strconv.Itoa(port))
+}
+
+// Sends msg, a complete email with headers, to every recipient. The connection is upgraded with
+// STARTTLS when the server offers it, and credentials are only sent over TLS or to localhost.
+func SendMail(c SMTPConfig, msg []byte) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+
+	return smtp.SendMail(c.addr(), auth, c.From, c.To, msg)
+}
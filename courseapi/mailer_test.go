@@ -0,0 +1,112 @@
+package courseapi
+
+import (
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// What a fake SMTP server was sent in one session
+type receivedMail struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// Starts a minimal SMTP server on localhost that accepts one message, returning its port
+func startFakeSMTPServer(t *testing.T) (int, <-chan receivedMail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan receivedMail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var mail receivedMail
+		tp.PrintfLine("220 localhost fake SMTP")
+
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(strings.Fields(line + " ")[0])
+			switch command {
+			case "EHLO":
+				tp.PrintfLine("250-localhost")
+				tp.PrintfLine("250 AUTH PLAIN")
+			case "AUTH":
+				mail.auth = line
+				tp.PrintfLine("235 OK")
+			case "MAIL":
+				mail.from = line
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				mail.to = append(mail.to, line)
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 Go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				mail.data = string(data)
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 Bye")
+				received <- mail
+				return
+			default:
+				tp.PrintfLine("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSendMail_Success(t *testing.T) {
+	port, received := startFakeSMTPServer(t)
+	config := SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		Username: "bot",
+		Password: "secret",
+		From:     "bot@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+	}
+
+	err := SendMail(config, []byte("Subject: Test\r\n\r\nHello\r\n"))
+	assert.NoError(t, err)
+
+	mail := <-received
+	assert.True(t, strings.HasPrefix(mail.auth, "AUTH PLAIN "))
+	assert.True(t, strings.HasPrefix(mail.from, "MAIL FROM:<bot@example.com>"))
+	assert.Equal(t, []string{"RCPT TO:<a@example.com>", "RCPT TO:<b@example.com>"}, mail.to)
+	assert.Equal(t, "Subject: Test\n\nHello\n", mail.data)
+}
+
+func TestSendMail_Failure(t *testing.T) {
+	assert.EqualError(t, SendMail(SMTPConfig{From: "a@example.com", To: []string{"b@example.com"}}, nil), MissingSMTPHostErrMsg)
+	assert.EqualError(t, SendMail(SMTPConfig{Host: "localhost", To: []string{"b@example.com"}}, nil), MissingMailFromErrMsg)
+	assert.EqualError(t, SendMail(SMTPConfig{Host: "localhost", From: "a@example.com"}, nil), MissingRecipientsErrMsg)
+
+	// Nothing is listening on a port that was just closed
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+	assert.Error(t, SendMail(SMTPConfig{Host: "127.0.0.1", Port: port, From: "a@example.com", To: []string{"b@example.com"}}, nil))
+	assert.Equal(t, "127.0.0.1:"+strconv.Itoa(DefaultSMTPPort), SMTPConfig{Host: "127.0.0.1"}.addr())
+}
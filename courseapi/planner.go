@@ -0,0 +1,348 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	InvalidAvailabilityErrMsg = "availability must look like MTWRF 18:00-21:00; SU 10:00-16:00"
+
+	// Used when no availability has been configured
+	DefaultAvailability = "MTWRF 18:00-21:00; SU 10:00-16:00"
+
+	// Work is planned in blocks of this many minutes
+	PlanBlockMinutes = 30
+
+	icsTimeFormat = "20060102T150405"
+)
+
+// A weekly window of time available for studying, e.g. weekday evenings
+type AvailabilityWindow struct {
+	Days  string
+	Start string
+	End   string
+}
+
+// Parses `;`-separated windows such as `MTWRF 18:00-21:00; SU 10:00-16:00`
+func ParseAvailability(spec string) ([]AvailabilityWindow, error) {
+	var windows []AvailabilityWindow
+	for _, part := range strings.Split(spec, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Windows are written like meeting times, just without a room
+		meeting, err := ParseMeeting(fields)
+		if err != nil || len(fields) != 2 {
+			return nil, fmt.Errorf("%s: `%s`", InvalidAvailabilityErrMsg, strings.TrimSpace(part))
+		}
+
+		windows = append(windows, AvailabilityWindow{Days: meeting.Days, Start: meeting.Start, End: meeting.End})
+	}
+
+	if len(windows) == 0 {
+		return nil, errors.New(InvalidAvailabilityErrMsg)
+	}
+
+	return windows, nil
+}
+
+func AvailabilityString(windows []AvailabilityWindow) string {
+	parts := make([]string, len(windows))
+	for i, w := range windows {
+		parts[i] = fmt.Sprintf("%s %s-%s", w.Days, w.Start, w.End)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+type planSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+// The concrete time spans available on day, in order. Windows that overlap or touch are merged,
+// so no time is counted or booked twice.
+func availableSpans(windows []AvailabilityWindow, day time.Time) []planSpan {
+	var spans []planSpan
+	for _, w := range windows {
+		if !strings.ContainsRune(w.Days, weekdayCode(day.Weekday())) {
+			continue
+		}
+
+		start, _ := time.Parse(MeetingTimeFormat, w.Start)
+		end, _ := time.Parse(MeetingTimeFormat, w.End)
+		spans = append(spans, planSpan{
+			start: time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, day.Location()),
+			end:   time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, day.Location()),
+		})
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+
+	var merged []planSpan
+	for _, span := range spans {
+		if n := len(merged); n > 0 && !span.start.After(merged[n-1].end) {
+			if span.end.After(merged[n-1].end) {
+				merged[n-1].end = span.end
+			}
+			continue
+		}
+
+		merged = append(merged, span)
+	}
+
+	return merged
+}
+
+func weekdayCode(day time.Weekday) rune {
+	for code, weekday := range meetingDayCodes {
+		if weekday == day {
+			return code
+		}
+	}
+
+	return 0
+}
+
+// A stretch of time set aside to work on one assignment
+type PlanBlock struct {
+	Start      time.Time
+	End        time.Time
+	Course     string
+	Number     int
+	Assignment string
+	DueAt      time.Time
+}
+
+func (b PlanBlock) String() string {
+	return fmt.Sprintf("%s-%s  %s %d. %s (due %s)", b.Start.Format(MeetingTimeFormat), b.End.Format(MeetingTimeFormat),
+		b.Course, b.Number, b.Assignment, b.DueAt.Format(DateFormat))
+}
+
+// Work that couldn't be fit into the available time before its deadline
+type PlanShortfall struct {
+	Course     string
+	Number     int
+	Assignment string
+	DueAt      time.Time
+	Hours      float64
+}
+
+// A schedule of work blocks for ungraded assignments with effort estimates
+type Plan struct {
+	Blocks      []PlanBlock
+	Shortfalls  []PlanShortfall
+	Unestimated int
+}
+
+type planTask struct {
+	match AssignmentMatch
+	// The task must be finished by the end of this day
+	lastDay int
+	blocks  int
+}
+
+// Plans work on every ungraded, estimated assignment due from today on, in from's time zone.
+// Assignments are taken earliest deadline first, and each one's blocks go to whichever days
+// before its deadline are least loaded so far, so work is spread out instead of piling up on
+// the first free evenings. Work is allowed up to the end of the day an assignment is due.
+func NewPlan(cm CourseMap, from time.Time, windows []AvailabilityWindow) Plan {
+	loc := from.Location()
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	blockLength := PlanBlockMinutes * time.Minute
+
+	var plan Plan
+	var tasks []planTask
+	for _, match := range (Filter{}).Find(cm, from) {
+		a := match.Assignment
+		due := time.Date(a.DueAt.Year(), a.DueAt.Month(), a.DueAt.Day(), 0, 0, 0, 0, loc)
+		if a.IsGraded() || due.Before(today) {
+			continue
+		}
+
+		if a.EstimatedHours == nil {
+			plan.Unestimated++
+			continue
+		}
+
+		blocks := int((*a.EstimatedHours*60 + PlanBlockMinutes - 1) / PlanBlockMinutes)
+		tasks = append(tasks, planTask{match: match, lastDay: int(due.Sub(today).Hours()/24 + 0.5), blocks: blocks})
+	}
+
+	if len(tasks) == 0 {
+		return plan
+	}
+
+	// Free blocks per day, not counting any time already past today. Blocks start on the half
+	// hour of from's own clock, even in zones offset from UTC by other than whole hours.
+	nextBlock := today.Add(from.Sub(today).Truncate(blockLength) + blockLength)
+	days := tasks[len(tasks)-1].lastDay + 1
+	daySpans := make([][]planSpan, days)
+	capacity := make([]int, days)
+	for d := range daySpans {
+		for _, span := range availableSpans(windows, today.AddDate(0, 0, d)) {
+			if span.start.Before(from) {
+				span.start = nextBlock
+			}
+			if span.end.After(span.start) {
+				daySpans[d] = append(daySpans[d], span)
+				capacity[d] += int(span.end.Sub(span.start) / blockLength)
+			}
+		}
+	}
+
+	// Blocks of each task assigned to each day
+	load := make([]int, days)
+	allocations := make([][]int, len(tasks))
+	for t, task := range tasks {
+		allocations[t] = make([]int, days)
+
+		remaining := task.blocks
+		for ; remaining > 0; remaining-- {
+			best := -1
+			for d := 0; d <= task.lastDay; d++ {
+				if load[d] < capacity[d] && (best < 0 || load[d] < load[best]) {
+					best = d
+				}
+			}
+
+			if best < 0 {
+				break
+			}
+
+			load[best]++
+			allocations[t][best]++
+		}
+
+		if remaining > 0 {
+			a := task.match.Assignment
+			plan.Shortfalls = append(plan.Shortfalls, PlanShortfall{
+				Course:     task.match.Course,
+				Number:     task.match.Number,
+				Assignment: a.Name,
+				DueAt:      a.DueAt,
+				Hours:      float64(remaining*PlanBlockMinutes) / 60,
+			})
+		}
+	}
+
+	// Lay each day's blocks out over its spans, earliest deadline first
+	for d := 0; d < days; d++ {
+		spanIndex := 0
+		var cursor time.Time
+		if len(daySpans[d]) > 0 {
+			cursor = daySpans[d][0].start
+		}
+
+		for t, task := range tasks {
+			for n := allocations[t][d]; n > 0; n-- {
+				for cursor.Add(blockLength).After(daySpans[d][spanIndex].end) {
+					spanIndex++
+					cursor = daySpans[d][spanIndex].start
+				}
+
+				plan.addBlock(task.match, cursor, cursor.Add(blockLength))
+				cursor = cursor.Add(blockLength)
+			}
+		}
+	}
+
+	return plan
+}
+
+// Appends a block, merging it into the previous one if it continues the same assignment
+func (p *Plan) addBlock(match AssignmentMatch, start time.Time, end time.Time) {
+	if n := len(p.Blocks); n > 0 {
+		last := &p.Blocks[n-1]
+		if last.Course == match.Course && last.Number == match.Number && last.End.Equal(start) {
+			last.End = end
+			return
+		}
+	}
+
+	p.Blocks = append(p.Blocks, PlanBlock{
+		Start:      start,
+		End:        end,
+		Course:     match.Course,
+		Number:     match.Number,
+		Assignment: match.Assignment.Name,
+		DueAt:      match.Assignment.DueAt,
+	})
+}
+
+// Lists the plan day by day, followed by any work that didn't fit
+func (p Plan) String() string {
+	result := ""
+	if len(p.Blocks) == 0 {
+		result = "Nothing to plan.\n"
+	}
+
+	var day time.Time
+	for _, b := range p.Blocks {
+		blockDay := time.Date(b.Start.Year(), b.Start.Month(), b.Start.Day(), 0, 0, 0, 0, b.Start.Location())
+		if !blockDay.Equal(day) {
+			if !day.IsZero() {
+				result += "\n"
+			}
+			day = blockDay
+			result += day.Format("Mon "+DateFormat) + "\n"
+		}
+
+		result += "  " + b.String() + "\n"
+	}
+
+	if len(p.Shortfalls) > 0 {
+		result += "\nNot enough available time for:\n"
+		for _, s := range p.Shortfalls {
+			result += fmt.Sprintf("  %s %d. %s (due %s): %g hours unplanned\n", s.Course, s.Number, s.Assignment, s.DueAt.Format(DateFormat), s.Hours)
+		}
+	}
+
+	if p.Unestimated > 0 {
+		result += fmt.Sprintf("\n%d ungraded assignment(s) have no effort estimate and weren't planned.\n", p.Unestimated)
+	}
+
+	return result
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// Writes the plan's blocks as iCalendar events, stamped with now. Times are written in
+// the plan's local time, without a time zone, so calendars show them as planned.
+func (p Plan) WriteICS(w io.Writer, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//go-sheets//study plan//EN",
+		"CALSCALE:GREGORIAN",
+	}
+
+	for _, b := range p.Blocks {
+		uid := fmt.Sprintf("%s-%d-%s@go-sheets", b.Course, b.Number, b.Start.Format(icsTimeFormat))
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeICSText(strings.ReplaceAll(uid, " ", "_")),
+			"DTSTAMP:"+now.UTC().Format(icsTimeFormat)+"Z",
+			"DTSTART:"+b.Start.Format(icsTimeFormat),
+			"DTEND:"+b.End.Format(icsTimeFormat),
+			"SUMMARY:"+escapeICSText(fmt.Sprintf("%s: %s", b.Course, b.Assignment)),
+			"DESCRIPTION:"+escapeICSText(fmt.Sprintf("Work on assignment %d, due %s", b.Number, b.DueAt.Format(DateFormat))),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\r\n")+"\r\n")
+	return err
+}
@@ -0,0 +1,132 @@
+package courseapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Monday, 02/03/25 at noon
+var plannerTestNow = time.Date(2025, 2, 3, 12, 0, 0, 0, time.UTC)
+
+func newPlannerTestWindows(t *testing.T) []AvailabilityWindow {
+	windows, err := ParseAvailability("MTWRF 18:00-20:00")
+	assert.NoError(t, err)
+
+	return windows
+}
+
+func TestParseAvailability_Success(t *testing.T) {
+	windows, err := ParseAvailability(DefaultAvailability)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []AvailabilityWindow{{"MTWRF", "18:00", "21:00"}, {"SU", "10:00", "16:00"}}, windows)
+	assert.Equal(t, DefaultAvailability, AvailabilityString(windows))
+}
+
+func TestParseAvailability_Failure(t *testing.T) {
+	_, err := ParseAvailability("MTWRF 18:00-21:00 library")
+	assert.ErrorContains(t, err, InvalidAvailabilityErrMsg)
+
+	_, err = ParseAvailability(" ; ")
+	assert.EqualError(t, err, InvalidAvailabilityErrMsg)
+}
+
+func TestNewPlan_SpreadsWorkBeforeDeadline_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Essay", "02/06/25"})
+	course.SetEffort(0, 4)
+
+	plan := NewPlan(CourseMap{"CS101": course}, plannerTestNow, newPlannerTestWindows(t))
+
+	// 4 hours over Mon-Thu evenings is an hour a night, rather than two full evenings
+	assert.Equal(t, 4, len(plan.Blocks))
+	for i, b := range plan.Blocks {
+		assert.Equal(t, time.Date(2025, 2, 3+i, 18, 0, 0, 0, time.UTC), b.Start)
+		assert.Equal(t, time.Hour, b.End.Sub(b.Start))
+	}
+	assert.Empty(t, plan.Shortfalls)
+}
+
+func TestNewPlan_EarliestDeadlineFirst_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab", "02/03/25"}, [2]string{"Project", "02/04/25"})
+	course.SetEffort(0, 1)
+	course.SetEffort(1, 2.5)
+
+	plan := NewPlan(CourseMap{"CS101": course}, plannerTestNow, newPlannerTestWindows(t))
+
+	// The lab is placed first, then the project is levelled across both evenings
+	expected := "Mon 02/03/25\n" +
+		"  18:00-19:00  CS101 1. Lab (due 02/03/25)\n" +
+		"  19:00-20:00  CS101 2. Project (due 02/04/25)\n" +
+		"\n" +
+		"Tue 02/04/25\n" +
+		"  18:00-19:30  CS101 2. Project (due 02/04/25)\n"
+	assert.Equal(t, expected, plan.String())
+}
+
+func TestNewPlan_ShortfallAndUnestimated_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Old Lab", "01/30/25"}, [2]string{"Project", "02/04/25"}, [2]string{"Reading", "02/05/25"}, [2]string{"Quiz", "02/07/25"})
+	course.SetEffort(0, 1)
+	course.SetEffort(1, 5)
+	course.SetEffort(2, 1)
+
+	// Starting at 19:10 leaves 19:30-20:00 on Monday
+	plan := NewPlan(CourseMap{"CS101": course}, plannerTestNow.Add(7*time.Hour+10*time.Minute), newPlannerTestWindows(t))
+
+	assert.Equal(t, time.Date(2025, 2, 3, 19, 30, 0, 0, time.UTC), plan.Blocks[0].Start)
+	assert.Equal(t, []PlanShortfall{{Course: "CS101", Number: 2, Assignment: "Project", DueAt: course.Assignments[1].DueAt, Hours: 2.5}}, plan.Shortfalls)
+	assert.Equal(t, 1, plan.Unestimated)
+	assert.Contains(t, plan.String(), "Not enough available time for:\n  CS101 2. Project (due 02/04/25): 2.5 hours unplanned\n")
+}
+
+func TestNewPlan_OverlappingWindows_Success(t *testing.T) {
+	windows, err := ParseAvailability("M 18:00-21:00; M 19:00-22:00; M 22:00-22:30")
+	assert.NoError(t, err)
+
+	course := newTestCourse("CS101", [2]string{"Project", "02/03/25"})
+	course.SetEffort(0, 8)
+
+	plan := NewPlan(CourseMap{"CS101": course}, plannerTestNow, windows)
+
+	// The windows merge into one evening, so no hour is booked twice
+	assert.Equal(t, []PlanBlock{{
+		Start: time.Date(2025, 2, 3, 18, 0, 0, 0, time.UTC), End: time.Date(2025, 2, 3, 22, 30, 0, 0, time.UTC),
+		Course: "CS101", Number: 1, Assignment: "Project", DueAt: course.Assignments[0].DueAt,
+	}}, plan.Blocks)
+	assert.Equal(t, 3.5, plan.Shortfalls[0].Hours)
+}
+
+func TestNewPlan_QuarterHourZone_Success(t *testing.T) {
+	kathmandu := time.FixedZone("NPT", 5*60*60+45*60)
+	course := newTestCourse("CS101", [2]string{"Lab", "02/03/25"})
+	course.SetEffort(0, 0.5)
+
+	// Starting at 19:10 local time, the first block starts on the local half hour
+	plan := NewPlan(CourseMap{"CS101": course}, time.Date(2025, 2, 3, 19, 10, 0, 0, kathmandu), newPlannerTestWindows(t))
+
+	assert.Equal(t, time.Date(2025, 2, 3, 19, 30, 0, 0, kathmandu), plan.Blocks[0].Start)
+}
+
+func TestNewPlan_NothingToPlan_Success(t *testing.T) {
+	assert.Equal(t, "Nothing to plan.\n", NewPlan(CourseMap{}, plannerTestNow, newPlannerTestWindows(t)).String())
+}
+
+func TestPlan_WriteICS_Success(t *testing.T) {
+	course := newTestCourse("CS 101", [2]string{"Essay, draft", "02/03/25"})
+	course.SetEffort(0, 1)
+	plan := NewPlan(CourseMap{"CS 101": course}, plannerTestNow, newPlannerTestWindows(t))
+
+	var buf bytes.Buffer
+	assert.NoError(t, plan.WriteICS(&buf, plannerTestNow))
+	ics := buf.String()
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "UID:CS_101-1-20250203T180000@go-sheets\r\n")
+	assert.Contains(t, ics, "DTSTAMP:20250203T120000Z\r\n")
+	assert.Contains(t, ics, "DTSTART:20250203T180000\r\nDTEND:20250203T190000\r\n")
+	assert.Contains(t, ics, "SUMMARY:CS 101: Essay\\, draft\r\n")
+}
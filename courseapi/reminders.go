@@ -0,0 +1,319 @@
+package courseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	InvalidReminderOffsetErrMsg = "reminder offsets must be positive amounts of days, hours or minutes such as 3d, 1d, 2h or 30m"
+
+	// Used for assignments without their own reminders, unless configured otherwise
+	DefaultReminderOffsets = "3d,1d,2h"
+
+	// Assignments overdue for longer than this are no longer reminded
+	OverdueReminderDays = 7
+)
+
+// Parses an offset before a deadline such as 3d, 2h or 30m
+func ParseReminderOffset(offset string) (time.Duration, error) {
+	offset = strings.ToLower(strings.TrimSpace(offset))
+	if len(offset) < 2 {
+		return 0, fmt.Errorf("%s: `%s`", InvalidReminderOffsetErrMsg, offset)
+	}
+
+	n, err := strconv.Atoi(offset[:len(offset)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s: `%s`", InvalidReminderOffsetErrMsg, offset)
+	}
+
+	switch offset[len(offset)-1] {
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'm':
+		return time.Duration(n) * time.Minute, nil
+	default:
+		return 0, fmt.Errorf("%s: `%s`", InvalidReminderOffsetErrMsg, offset)
+	}
+}
+
+// Parses comma or space separated offsets, e.g. `3d, 1d, 2h`, longest first with duplicates removed
+func ParseReminderOffsets(spec string) ([]time.Duration, error) {
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(fields) == 0 {
+		return nil, errors.New(InvalidReminderOffsetErrMsg)
+	}
+
+	seen := make(map[time.Duration]bool)
+	var offsets []time.Duration
+	for _, field := range fields {
+		offset, err := ParseReminderOffset(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+
+	sort.Slice(offsets, func(i, j int) bool {
+		return offsets[i] > offsets[j]
+	})
+
+	return offsets, nil
+}
+
+// Formats an offset in the largest whole unit, e.g. 48h as 2d
+func FormatReminderOffset(offset time.Duration) string {
+	switch {
+	case offset%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", offset/(24*time.Hour))
+	case offset%time.Hour == 0:
+		return fmt.Sprintf("%dh", offset/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", offset/time.Minute)
+	}
+}
+
+func ReminderOffsetsString(offsets []time.Duration) string {
+	parts := make([]string, len(offsets))
+	for i, offset := range offsets {
+		parts[i] = FormatReminderOffset(offset)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Gives the assignment at index its own reminder offsets, or goes back to the defaults if there are none
+func (c *CourseItem) SetReminders(index int, offsets []time.Duration) error {
+	a, err := c.assignment(index)
+	if err != nil {
+		return err
+	}
+
+	a.Reminders = nil
+	for _, offset := range offsets {
+		a.Reminders = append(a.Reminders, FormatReminderOffset(offset))
+	}
+
+	return nil
+}
+
+// An assignment's own reminder offsets, falling back to defaults
+func (a AssignmentItem) reminderOffsets(defaults []time.Duration) []time.Duration {
+	if len(a.Reminders) == 0 {
+		return defaults
+	}
+
+	offsets, err := ParseReminderOffsets(strings.Join(a.Reminders, ","))
+	if err != nil {
+		return defaults
+	}
+
+	return offsets
+}
+
+// Due dates are calendar days, so an assignment is due by the end of its day in loc
+func reminderDeadline(dueAt time.Time, loc *time.Location) time.Time {
+	return time.Date(dueAt.Year(), dueAt.Month(), dueAt.Day()+1, 0, 0, 0, 0, loc)
+}
+
+// A notice that an assignment is due soon, fired Offset before its deadline. An Offset of 0
+// fires at the deadline itself, for an assignment that became overdue.
+type Reminder struct {
+	Course     string
+	Assignment string
+	DueAt      time.Time
+	Deadline   time.Time
+	Offset     time.Duration
+	FireAt     time.Time
+}
+
+func (r Reminder) Overdue() bool {
+	return r.Offset == 0
+}
+
+// Identifies the reminder across runs, so it is only delivered once
+func (r Reminder) Key() string {
+	when := "overdue"
+	if !r.Overdue() {
+		when = FormatReminderOffset(r.Offset)
+	}
+
+	return fmt.Sprintf("%s|%s", r.assignmentKey(), when)
+}
+
+// Identifies the reminder's assignment. The due date is written in a fixed format, so changing
+// the date format doesn't make delivered reminders look new.
+func (r Reminder) assignmentKey() string {
+	return fmt.Sprintf("%s|%s|%s", r.Course, r.Assignment, r.DueAt.Format(time.RFC3339))
+}
+
+// Describes the reminder as of now, e.g. `CS101 Lab 2 is due 02/09/25 (in 1 day(s))`
+func (r Reminder) String(now time.Time) string {
+	left := r.Deadline.Sub(now)
+	var when string
+	switch {
+	case left <= 0:
+		when = "past due"
+	case left >= 24*time.Hour:
+		when = fmt.Sprintf("in %d day(s)", int(left/(24*time.Hour)))
+	case left >= time.Hour:
+		when = fmt.Sprintf("in %d hour(s)", int(left/time.Hour))
+	default:
+		when = fmt.Sprintf("in %d minute(s)", int(left/time.Minute)+1)
+	}
+
+	return fmt.Sprintf("%s %s is due %s (%s)", r.Course, r.Assignment, r.DueAt.Format(DateFormat), when)
+}
+
+// Every reminder for assignments across cm that aren't finished, ordered by when they fire, plus
+// one at each deadline in loc for when the assignment becomes overdue. Assignments without their
+// own offsets use defaults, and those overdue for more than OverdueReminderDays as of now are left out.
+func (cm CourseMap) Reminders(defaults []time.Duration, now time.Time, loc *time.Location) []Reminder {
+	var reminders []Reminder
+	for courseName, course := range cm {
+		for _, a := range course.Assignments {
+			deadline := reminderDeadline(a.DueAt, loc)
+			if a.IsComplete() || !deadline.After(now.AddDate(0, 0, -OverdueReminderDays)) {
+				continue
+			}
+
+			offsets := append(slices.Clone(a.reminderOffsets(defaults)), 0)
+			for _, offset := range offsets {
+				reminders = append(reminders, Reminder{
+					Course:     courseName,
+					Assignment: a.Name,
+					DueAt:      a.DueAt,
+					Deadline:   deadline,
+					Offset:     offset,
+					FireAt:     deadline.Add(-offset),
+				})
+			}
+		}
+	}
+
+	sort.Slice(reminders, func(i, j int) bool {
+		a, b := reminders[i], reminders[j]
+		if !a.FireAt.Equal(b.FireAt) {
+			return a.FireAt.Before(b.FireAt)
+		}
+		return a.Key() < b.Key()
+	})
+
+	return reminders
+}
+
+// Remembers which reminders were delivered, so watching again doesn't repeat them. Each
+// spreadsheet and tab, by SheetScope, keeps its own record, so delivering one term's reminders
+// never forgets another's.
+type ReminderLog struct {
+	mu        sync.Mutex
+	delivered map[string]map[string]time.Time
+}
+
+func NewReminderLog() *ReminderLog {
+	return &ReminderLog{delivered: make(map[string]map[string]time.Time)}
+}
+
+func LoadReminderLog(r io.Reader) (*ReminderLog, error) {
+	l := NewReminderLog()
+	err := json.NewDecoder(r).Decode(&l.delivered)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.delivered == nil {
+		l.delivered = make(map[string]map[string]time.Time)
+	}
+	return l, nil
+}
+
+func (l *ReminderLog) Save(w io.Writer) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return json.NewEncoder(w).Encode(l.delivered)
+}
+
+func (l *ReminderLog) Delivered(scope string, r Reminder) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, delivered := l.delivered[scope][r.Key()]
+	return delivered
+}
+
+// Returns the reminders that have fired by now and weren't delivered yet, recording them as
+// delivered. If several of an assignment's reminders fired since the last delivery, e.g. because
+// nothing was watching, only the latest is returned.
+func (l *ReminderLog) Deliver(scope string, reminders []Reminder, now time.Time) []Reminder {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delivered := l.delivered[scope]
+	if delivered == nil {
+		delivered = make(map[string]time.Time)
+		l.delivered[scope] = delivered
+	}
+
+	latest := make(map[string]int)
+	var due []Reminder
+	for _, r := range reminders {
+		if r.FireAt.After(now) {
+			continue
+		}
+
+		if _, exists := delivered[r.Key()]; exists {
+			continue
+		}
+		delivered[r.Key()] = now
+
+		// Reminders are ordered by when they fire, so a later one replaces an earlier one
+		assignment := r.assignmentKey()
+		if i, exists := latest[assignment]; exists {
+			due[i] = r
+			continue
+		}
+
+		latest[assignment] = len(due)
+		due = append(due, r)
+	}
+
+	return due
+}
+
+// Forgets the scope's reminders that no longer exist, e.g. because their assignment is long
+// overdue or finished. Other scopes are left alone.
+func (l *ReminderLog) Prune(scope string, reminders []Reminder) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := make(map[string]bool, len(reminders))
+	for _, r := range reminders {
+		current[r.Key()] = true
+	}
+
+	for key := range l.delivered[scope] {
+		if !current[key] {
+			delete(l.delivered[scope], key)
+		}
+	}
+	if len(l.delivered[scope]) == 0 {
+		delete(l.delivered, scope)
+	}
+}
@@ -0,0 +1,125 @@
+package courseapi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReminderOffsets_Success(t *testing.T) {
+	offsets, err := ParseReminderOffsets("2h, 3d,1d 2h 90m")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{72 * time.Hour, 24 * time.Hour, 2 * time.Hour, 90 * time.Minute}, offsets)
+	assert.Equal(t, "3d, 1d, 2h, 90m", ReminderOffsetsString(offsets))
+	assert.Equal(t, "2d", FormatReminderOffset(48*time.Hour))
+}
+
+func TestParseReminderOffsets_Failure(t *testing.T) {
+	for _, spec := range []string{"", "3", "0d", "-1h", "2w", "d"} {
+		_, err := ParseReminderOffsets(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCourseItem_SetReminders_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})
+
+	assert.NoError(t, course.SetReminders(0, []time.Duration{time.Hour, 48 * time.Hour}))
+	assert.Equal(t, []string{"1h", "2d"}, course.Assignments[0].Reminders)
+	assert.Equal(t, "Lab 1\nDue: 02/02/25\nReminders: 1h, 2d before", course.Assignments[0].String())
+
+	cpy := course.DeepCopy()
+	cpy.Assignments[0].Reminders[0] = "5m"
+	assert.Equal(t, "1h", course.Assignments[0].Reminders[0])
+
+	assert.NoError(t, course.SetReminders(0, nil))
+	assert.Nil(t, course.Assignments[0].Reminders)
+	assert.EqualError(t, course.SetReminders(1, nil), InvalidSliceRemoveErrMsg)
+}
+
+func TestCourseMap_Reminders_Success(t *testing.T) {
+	cm := CourseMap{"CS101": newTestCourse("CS101",
+		[2]string{"Lab 1", "02/02/25"},
+		[2]string{"Lab 2", "02/09/25"},
+		[2]string{"Quiz", "02/10/25"})}
+	cm["CS101"].SetReminders(1, []time.Duration{time.Hour})
+	cm["CS101"].Assignments[2].PointsEarned = new(float64)
+	cm["CS101"].Assignments[2].PointsPossible = new(float64)
+
+	defaults, _ := ParseReminderOffsets(DefaultReminderOffsets)
+	now := time.Date(2025, 2, 2, 12, 0, 0, 0, time.UTC)
+	reminders := cm.Reminders(defaults, now, time.UTC)
+
+	// Lab 1 is due by the end of 02/02, Lab 2 has its own offset and the graded quiz has none.
+	// Each also fires once at its deadline, for when it becomes overdue.
+	assert.Equal(t, 6, len(reminders))
+	assert.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), reminders[0].FireAt)
+	assert.Equal(t, time.Date(2025, 2, 9, 23, 0, 0, 0, time.UTC), reminders[4].FireAt)
+	assert.Equal(t, "CS101 Lab 1 is due 02/02/25 (in 12 hour(s))", reminders[1].String(now))
+	assert.Equal(t, "CS101|Lab 2|2025-02-09T00:00:00Z|1h", reminders[4].Key())
+	assert.True(t, reminders[3].Overdue())
+	assert.Equal(t, "CS101|Lab 1|2025-02-02T00:00:00Z|overdue", reminders[3].Key())
+	assert.Equal(t, "CS101 Lab 1 is due 02/02/25 (past due)", reminders[3].String(now.Add(24*time.Hour)))
+
+	// Long overdue assignments aren't reminded any more
+	assert.Equal(t, 2, len(cm.Reminders(defaults, now.AddDate(0, 0, OverdueReminderDays+1), time.UTC)))
+
+	loc := time.FixedZone("EST", -5*60*60)
+	assert.Equal(t, time.Date(2025, 2, 3, 0, 0, 0, 0, loc), cm.Reminders(defaults, now, loc)[0].Deadline)
+}
+
+func TestReminderLog_Deliver_Success(t *testing.T) {
+	cm := CourseMap{"CS101": newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})}
+	defaults, _ := ParseReminderOffsets(DefaultReminderOffsets)
+	l := NewReminderLog()
+
+	// The 3 day and 1 day reminders have both fired, but only the latest is delivered
+	now := time.Date(2025, 2, 2, 12, 0, 0, 0, time.UTC)
+	reminders := cm.Reminders(defaults, now, time.UTC)
+	due := l.Deliver("sheet/Fall", reminders, now)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, 24*time.Hour, due[0].Offset)
+	assert.True(t, l.Delivered("sheet/Fall", reminders[0]))
+	assert.Empty(t, l.Deliver("sheet/Fall", reminders, now))
+
+	var buf bytes.Buffer
+	assert.NoError(t, l.Save(&buf))
+	loaded, err := LoadReminderLog(&buf)
+	assert.NoError(t, err)
+
+	now = now.Add(10 * time.Hour)
+	due = loaded.Deliver("sheet/Fall", reminders, now)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, 2*time.Hour, due[0].Offset)
+
+	due = loaded.Deliver("sheet/Fall", reminders, now.Add(2*time.Hour))
+	assert.Equal(t, 1, len(due))
+	assert.True(t, due[0].Overdue())
+
+	// Once the assignment is long overdue its reminders are gone, and so are their records
+	loaded.Prune("sheet/Fall", cm.Reminders(defaults, now.AddDate(0, 0, OverdueReminderDays+1), time.UTC))
+	assert.False(t, loaded.Delivered("sheet/Fall", reminders[0]))
+}
+
+func TestReminderLog_Scopes_Success(t *testing.T) {
+	cm := CourseMap{"CS101": newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})}
+	defaults, _ := ParseReminderOffsets(DefaultReminderOffsets)
+	now := time.Date(2025, 2, 2, 12, 0, 0, 0, time.UTC)
+	reminders := cm.Reminders(defaults, now, time.UTC)
+
+	l := NewReminderLog()
+	assert.Len(t, l.Deliver("sheet/Fall", reminders, now), 1)
+
+	// Another term has its own record, and pruning it leaves the first term's alone
+	assert.False(t, l.Delivered("sheet/Spring", reminders[0]))
+	l.Prune("sheet/Spring", nil)
+	assert.True(t, l.Delivered("sheet/Fall", reminders[0]))
+	assert.Empty(t, l.Deliver("sheet/Fall", reminders, now))
+
+	// Keys don't depend on how dates are shown
+	DateFormat, _ = ParseDatePattern("YYYY-MM-DD")
+	t.Cleanup(func() { DateFormat = DefaultDateFormat })
+	assert.True(t, l.Delivered("sheet/Fall", reminders[0]))
+}
@@ -0,0 +1,394 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Meeting times are given on a 24-hour clock, e.g. `13:30`
+	MeetingTimeFormat = "15:04"
+
+	InvalidMeetingErrMsg      = "meetings must look like MWF 10:00-10:50 (days from MTWRFSU, 24-hour times)"
+	InvalidMeetingTimesErrMsg = "meeting must end after it starts"
+	InvalidCourseDatesErrMsg  = "course end date must be after its start date"
+	InvalidLinkErrMsg         = "links must be absolute URLs such as https://example.com"
+	UnknownDetailErrMsg       = "unknown course detail (use instructor, room or office-hours)"
+	InvalidDetailIndexErrMsg  = "no meeting or link at that number"
+)
+
+// Single-letter day codes as used on most registrar timetables: R is Thursday and U is Sunday
+var meetingDayCodes = map[rune]time.Weekday{
+	'M': time.Monday,
+	'T': time.Tuesday,
+	'W': time.Wednesday,
+	'R': time.Thursday,
+	'F': time.Friday,
+	'S': time.Saturday,
+	'U': time.Sunday,
+}
+
+// Monday-first order used when listing days
+var weekOrder = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// A recurring weekly class meeting
+type Meeting struct {
+	Days  string `json:"days"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Room  string `json:"room,omitempty"`
+}
+
+// Structured course metadata beyond the free-form Course_Info
+type CourseDetails struct {
+	Instructor  string     `json:"instructor,omitempty"`
+	Room        string     `json:"room,omitempty"`
+	OfficeHours string     `json:"office_hours,omitempty"`
+	Meetings    []Meeting  `json:"meetings,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Links       []string   `json:"links,omitempty"`
+}
+
+func (d CourseDetails) DeepCopy() CourseDetails {
+	cpy := d
+
+	if d.Meetings != nil {
+		cpy.Meetings = make([]Meeting, len(d.Meetings))
+		copy(cpy.Meetings, d.Meetings)
+	}
+
+	if d.Links != nil {
+		cpy.Links = make([]string, len(d.Links))
+		copy(cpy.Links, d.Links)
+	}
+
+	if d.StartDate != nil {
+		startCopy := *d.StartDate
+		cpy.StartDate = &startCopy
+	}
+
+	if d.EndDate != nil {
+		endCopy := *d.EndDate
+		cpy.EndDate = &endCopy
+	}
+
+	return cpy
+}
+
+func (d CourseDetails) isEmpty() bool {
+	return d.Instructor == "" && d.Room == "" && d.OfficeHours == "" && len(d.Meetings) == 0 &&
+		d.StartDate == nil && d.EndDate == nil && len(d.Links) == 0
+}
+
+// Parses a meeting such as `MWF 10:00-10:50 [<room>]`
+func ParseMeeting(args []string) (Meeting, error) {
+	if len(args) < 2 {
+		return Meeting{}, errors.New(InvalidMeetingErrMsg)
+	}
+
+	days := strings.ToUpper(args[0])
+	seen := make(map[rune]bool)
+	for _, code := range days {
+		if _, valid := meetingDayCodes[code]; !valid || seen[code] {
+			return Meeting{}, errors.New(InvalidMeetingErrMsg)
+		}
+		seen[code] = true
+	}
+
+	startStr, endStr, found := strings.Cut(args[1], "-")
+	start, startErr := time.Parse(MeetingTimeFormat, startStr)
+	end, endErr := time.Parse(MeetingTimeFormat, endStr)
+	if !found || startErr != nil || endErr != nil {
+		return Meeting{}, errors.New(InvalidMeetingErrMsg)
+	}
+
+	if !end.After(start) {
+		return Meeting{}, errors.New(InvalidMeetingTimesErrMsg)
+	}
+
+	return Meeting{
+		Days:  days,
+		Start: start.Format(MeetingTimeFormat),
+		End:   end.Format(MeetingTimeFormat),
+		Room:  strings.Join(args[2:], " "),
+	}, nil
+}
+
+func (m Meeting) weekdays() []time.Weekday {
+	var weekdays []time.Weekday
+	for _, code := range m.Days {
+		weekdays = append(weekdays, meetingDayCodes[code])
+	}
+
+	return weekdays
+}
+
+func (m Meeting) String() string {
+	result := fmt.Sprintf("%s %s-%s", m.Days, m.Start, m.End)
+	if m.Room != "" {
+		result += fmt.Sprintf(" (%s)", m.Room)
+	}
+
+	return result
+}
+
+// Runs edit against the course's details, creating them if needed and dropping them again once empty
+func (c *CourseItem) editDetails(edit func(*CourseDetails) error) error {
+	details := CourseDetails{}
+	if c.Details != nil {
+		details = c.Details.DeepCopy()
+	}
+
+	err := edit(&details)
+	if err != nil {
+		return err
+	}
+
+	if details.isEmpty() {
+		c.Details = nil
+	} else {
+		c.Details = &details
+	}
+
+	return nil
+}
+
+// Sets instructor, room or office-hours; an empty value clears it
+func (c *CourseItem) SetDetail(field string, value string) error {
+	return c.editDetails(func(d *CourseDetails) error {
+		switch field {
+		case "instructor":
+			d.Instructor = value
+		case "room":
+			d.Room = value
+		case "office-hours":
+			d.OfficeHours = value
+		default:
+			return errors.New(UnknownDetailErrMsg)
+		}
+
+		return nil
+	})
+}
+
+// Sets the dates the course runs between, both given in DateFormat
+func (c *CourseItem) SetCourseDates(start string, end string) error {
+	startDate, err := time.Parse(DateFormat, start)
+	if err != nil {
+		return invalidDateError()
+	}
+
+	endDate, err := time.Parse(DateFormat, end)
+	if err != nil {
+		return invalidDateError()
+	}
+
+	if !endDate.After(startDate) {
+		return errors.New(InvalidCourseDatesErrMsg)
+	}
+
+	return c.editDetails(func(d *CourseDetails) error {
+		d.StartDate, d.EndDate = &startDate, &endDate
+		return nil
+	})
+}
+
+func (c *CourseItem) AddMeeting(meeting Meeting) error {
+	return c.editDetails(func(d *CourseDetails) error {
+		d.Meetings = append(d.Meetings, meeting)
+		return nil
+	})
+}
+
+func (c *CourseItem) RemoveMeeting(index int) error {
+	return c.editDetails(func(d *CourseDetails) error {
+		if index < 0 || index >= len(d.Meetings) {
+			return errors.New(InvalidDetailIndexErrMsg)
+		}
+
+		d.Meetings = append(d.Meetings[:index], d.Meetings[index+1:]...)
+		return nil
+	})
+}
+
+func (c *CourseItem) AddLink(link string) error {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return errors.New(InvalidLinkErrMsg)
+	}
+
+	return c.editDetails(func(d *CourseDetails) error {
+		d.Links = append(d.Links, link)
+		return nil
+	})
+}
+
+func (c *CourseItem) RemoveLink(index int) error {
+	return c.editDetails(func(d *CourseDetails) error {
+		if index < 0 || index >= len(d.Links) {
+			return errors.New(InvalidDetailIndexErrMsg)
+		}
+
+		d.Links = append(d.Links[:index], d.Links[index+1:]...)
+		return nil
+	})
+}
+
+// Lists the course's metadata, numbering meetings and links so they can be removed
+func (c CourseItem) DetailsString() string {
+	result := c.String()
+	if c.Details == nil {
+		return result + "\nNo details set."
+	}
+
+	d := c.Details
+	if d.Instructor != "" {
+		result += fmt.Sprintf("\nInstructor: %s", d.Instructor)
+	}
+	if d.Room != "" {
+		result += fmt.Sprintf("\nRoom: %s", d.Room)
+	}
+	if d.OfficeHours != "" {
+		result += fmt.Sprintf("\nOffice hours: %s", d.OfficeHours)
+	}
+	if d.StartDate != nil && d.EndDate != nil {
+		result += fmt.Sprintf("\nRuns: %s - %s", d.StartDate.Format(DateFormat), d.EndDate.Format(DateFormat))
+	}
+	if len(d.Meetings) > 0 {
+		result += "\nMeetings:"
+		for i, m := range d.Meetings {
+			result += fmt.Sprintf("\n  %d. %s", i+1, m.String())
+		}
+	}
+	if len(d.Links) > 0 {
+		result += "\nLinks:"
+		for i, link := range d.Links {
+			result += fmt.Sprintf("\n  %d. %s", i+1, link)
+		}
+	}
+
+	return result
+}
+
+// One course meeting on one day of the week
+type TimetableSlot struct {
+	Course string
+	Day    time.Weekday
+	Start  string
+	End    string
+	Room   string
+}
+
+func (s TimetableSlot) overlaps(other TimetableSlot) bool {
+	// Zero-padded 24-hour times compare correctly as strings
+	return s.Day == other.Day && s.Start < other.End && other.Start < s.End
+}
+
+// Two meetings that happen at the same time
+type MeetingOverlap struct {
+	First  TimetableSlot
+	Second TimetableSlot
+}
+
+func (o MeetingOverlap) String() string {
+	return fmt.Sprintf("%s: %s %s-%s overlaps %s %s-%s", o.First.Day, o.First.Course, o.First.Start, o.First.End,
+		o.Second.Course, o.Second.Start, o.Second.End)
+}
+
+// Every course meeting in the week, ordered by day then start time
+type Timetable struct {
+	Slots    []TimetableSlot
+	Overlaps []MeetingOverlap
+}
+
+// Lays out the meetings of every course in cm into a week, detecting any that overlap.
+// A meeting without its own room uses the course's room.
+func WeeklyTimetable(cm CourseMap) Timetable {
+	var t Timetable
+	for _, course := range cm {
+		if course.Details == nil {
+			continue
+		}
+
+		for _, m := range course.Details.Meetings {
+			room := m.Room
+			if room == "" {
+				room = course.Details.Room
+			}
+
+			for _, day := range m.weekdays() {
+				t.Slots = append(t.Slots, TimetableSlot{Course: course.Name, Day: day, Start: m.Start, End: m.End, Room: room})
+			}
+		}
+	}
+
+	dayIndex := func(day time.Weekday) int {
+		return (int(day) + 6) % 7 // Monday first
+	}
+	sort.Slice(t.Slots, func(i, j int) bool {
+		a, b := t.Slots[i], t.Slots[j]
+		if a.Day != b.Day {
+			return dayIndex(a.Day) < dayIndex(b.Day)
+		}
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.Course < b.Course
+	})
+
+	for i := range t.Slots {
+		for j := i + 1; j < len(t.Slots) && t.Slots[j].Day == t.Slots[i].Day; j++ {
+			if t.Slots[i].overlaps(t.Slots[j]) {
+				t.Overlaps = append(t.Overlaps, MeetingOverlap{First: t.Slots[i], Second: t.Slots[j]})
+			}
+		}
+	}
+
+	return t
+}
+
+func (t Timetable) String() string {
+	if len(t.Slots) == 0 {
+		return "No meeting times set.\n"
+	}
+
+	result := ""
+	for _, day := range weekOrder {
+		dayResult := ""
+		for _, s := range t.Slots {
+			if s.Day != day {
+				continue
+			}
+
+			dayResult += fmt.Sprintf("  %s-%s  %s", s.Start, s.End, s.Course)
+			if s.Room != "" {
+				dayResult += fmt.Sprintf(" (%s)", s.Room)
+			}
+			for _, o := range t.Overlaps {
+				if o.First == s || o.Second == s {
+					dayResult += "  [overlap]"
+					break
+				}
+			}
+			dayResult += "\n"
+		}
+
+		if dayResult != "" {
+			result += fmt.Sprintf("%s\n%s", day, dayResult)
+		}
+	}
+
+	if len(t.Overlaps) > 0 {
+		result += "\nOverlapping meetings:\n"
+		for _, o := range t.Overlaps {
+			result += fmt.Sprintf("  %s\n", o.String())
+		}
+	}
+
+	return result
+}
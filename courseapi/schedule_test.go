@@ -0,0 +1,90 @@
+package courseapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMeeting_Success(t *testing.T) {
+	meeting, err := ParseMeeting([]string{"mwf", "9:00-9:50", "Hall", "101"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Meeting{Days: "MWF", Start: "09:00", End: "09:50", Room: "Hall 101"}, meeting)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Wednesday, time.Friday}, meeting.weekdays())
+}
+
+func TestParseMeeting_Invalid_Failure(t *testing.T) {
+	_, err := ParseMeeting([]string{"MXF", "09:00-09:50"})
+	assert.EqualError(t, err, InvalidMeetingErrMsg)
+
+	_, err = ParseMeeting([]string{"MM", "09:00-09:50"})
+	assert.EqualError(t, err, InvalidMeetingErrMsg)
+
+	_, err = ParseMeeting([]string{"TR", "9am"})
+	assert.EqualError(t, err, InvalidMeetingErrMsg)
+
+	_, err = ParseMeeting([]string{"TR", "10:00-09:00"})
+	assert.EqualError(t, err, InvalidMeetingTimesErrMsg)
+}
+
+func TestCourseItem_SetDetail_ClearingLastDetail_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+
+	assert.NoError(t, course.SetDetail("instructor", "Dr. Lee"))
+	assert.Equal(t, "Dr. Lee", course.Details.Instructor)
+
+	copy := course.DeepCopy()
+	assert.NoError(t, copy.SetDetail("instructor", ""))
+	assert.Nil(t, copy.Details)
+	assert.Equal(t, "Dr. Lee", course.Details.Instructor)
+
+	assert.EqualError(t, course.SetDetail("ta", "Sam"), UnknownDetailErrMsg)
+}
+
+func TestCourseItem_SetCourseDates_Failure(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+
+	assert.EqualError(t, course.SetCourseDates("05/01/25", "01/15/25"), InvalidCourseDatesErrMsg)
+	assert.EqualError(t, course.SetCourseDates("1/15", "05/01/25"), invalidDateError().Error())
+	assert.Nil(t, course.Details)
+
+	assert.NoError(t, course.SetCourseDates("01/15/25", "05/01/25"))
+	assert.Contains(t, course.DetailsString(), "Runs: 01/15/25 - 05/01/25")
+}
+
+func TestCourseItem_LinksAndMeetings_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+
+	assert.EqualError(t, course.AddLink("not a url"), InvalidLinkErrMsg)
+	assert.NoError(t, course.AddLink("https://example.edu/cs101"))
+	assert.NoError(t, course.AddMeeting(Meeting{Days: "TR", Start: "13:00", End: "14:15"}))
+
+	assert.Equal(t, "Course: CS101\nMeetings:\n  1. TR 13:00-14:15\nLinks:\n  1. https://example.edu/cs101", course.DetailsString())
+
+	assert.EqualError(t, course.RemoveLink(1), InvalidDetailIndexErrMsg)
+	assert.NoError(t, course.RemoveLink(0))
+	assert.NoError(t, course.RemoveMeeting(0))
+	assert.Nil(t, course.Details)
+}
+
+func TestWeeklyTimetable_Overlaps_Success(t *testing.T) {
+	cs := &CourseItem{Name: "CS101", Details: &CourseDetails{Room: "Lab 3", Meetings: []Meeting{{Days: "MW", Start: "10:00", End: "11:15"}}}}
+	math := &CourseItem{Name: "MATH200", Details: &CourseDetails{Meetings: []Meeting{{Days: "WF", Start: "11:00", End: "11:50", Room: "Hall 2"}}}}
+	art := &CourseItem{Name: "ART100", Details: &CourseDetails{Meetings: []Meeting{{Days: "M", Start: "11:15", End: "12:00"}}}}
+
+	timetable := WeeklyTimetable(CourseMap{"CS101": cs, "MATH200": math, "ART100": art, "EMPTY": {Name: "EMPTY"}})
+
+	assert.Equal(t, 5, len(timetable.Slots))
+	assert.Equal(t, "Lab 3", timetable.Slots[0].Room)
+
+	// Back-to-back meetings don't overlap, so only Wednesday's do
+	assert.Equal(t, 1, len(timetable.Overlaps))
+	assert.Equal(t, "Wednesday: CS101 10:00-11:15 overlaps MATH200 11:00-11:50", timetable.Overlaps[0].String())
+	assert.Contains(t, timetable.String(), "Wednesday\n  10:00-11:15  CS101 (Lab 3)  [overlap]\n")
+}
+
+func TestWeeklyTimetable_NoMeetings_Success(t *testing.T) {
+	assert.Equal(t, "No meeting times set.\n", WeeklyTimetable(CourseMap{}).String())
+}
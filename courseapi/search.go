@@ -0,0 +1,296 @@
+package courseapi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Matches in names count for more than matches in descriptions and notes
+	searchNameWeight = 3.0
+	searchInfoWeight = 1.0
+
+	// Query words at least this long also match longer words they start, e.g. `tree` matches `trees`
+	searchMinPrefixLength = 3
+	searchPrefixPenalty   = 0.5
+
+	searchSnippetLength = 100
+)
+
+// A course (Number 0) or one of its assignments, as indexed for search
+type searchDoc struct {
+	Course string
+	Number int
+	Name   string
+	Info   string
+}
+
+type searchPosting struct {
+	doc    int
+	weight float64
+}
+
+// An inverted index over course names, course info, assignment names and assignment info.
+// It is a read-only view of the courses it was built from.
+type SearchIndex struct {
+	docs       []searchDoc
+	postings   map[string][]searchPosting
+	vocabulary []string
+}
+
+// Splits text into lowercase words, treating anything but letters and digits as a separator
+func searchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func NewSearchIndex(cm CourseMap) *SearchIndex {
+	idx := &SearchIndex{postings: make(map[string][]searchPosting)}
+
+	names := make([]string, 0, len(cm))
+	for name := range cm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		course := cm[name]
+		info := ""
+		if course.Course_Info != nil {
+			info = *course.Course_Info
+		}
+		idx.add(searchDoc{Course: name, Name: name, Info: info})
+
+		for i, a := range course.Assignments {
+			info := ""
+			if a.Info != nil {
+				info = *a.Info
+			}
+			idx.add(searchDoc{Course: name, Number: i + 1, Name: a.Name, Info: info})
+		}
+	}
+
+	for token := range idx.postings {
+		idx.vocabulary = append(idx.vocabulary, token)
+	}
+	sort.Strings(idx.vocabulary)
+
+	return idx
+}
+
+func (idx *SearchIndex) add(doc searchDoc) {
+	weights := make(map[string]float64)
+	for _, token := range searchTokens(doc.Name) {
+		weights[token] += searchNameWeight
+	}
+	for _, token := range searchTokens(doc.Info) {
+		weights[token] += searchInfoWeight
+	}
+
+	id := len(idx.docs)
+	idx.docs = append(idx.docs, doc)
+	for token, weight := range weights {
+		idx.postings[token] = append(idx.postings[token], searchPosting{doc: id, weight: weight})
+	}
+}
+
+// The indexed words a query word matches: itself, plus any it is a prefix of if long enough
+func (idx *SearchIndex) expand(term string) []string {
+	if len(term) < searchMinPrefixLength {
+		if _, exists := idx.postings[term]; exists {
+			return []string{term}
+		}
+		return nil
+	}
+
+	var tokens []string
+	start := sort.SearchStrings(idx.vocabulary, term)
+	for i := start; i < len(idx.vocabulary) && strings.HasPrefix(idx.vocabulary[i], term); i++ {
+		tokens = append(tokens, idx.vocabulary[i])
+	}
+
+	return tokens
+}
+
+// One course or assignment matching a search. Number is 0 when the course itself matched.
+type SearchResult struct {
+	Course       string
+	Number       int
+	Name         string
+	Info         string
+	Score        float64
+	MatchedTerms int
+	// The indexed words that matched, used for highlighting
+	words map[string]bool
+}
+
+// Ranks every course and assignment against the query words. Results matching more of the
+// words come first, then those scoring higher, where rarer words and matches in names
+// score more. At most limit results are returned, or all of them if limit is 0.
+func (idx *SearchIndex) Search(query string, limit int) []SearchResult {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range searchTokens(query) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	results := make(map[int]*SearchResult)
+	for _, term := range terms {
+		tokens := idx.expand(term)
+
+		matchedDocs := make(map[int]bool)
+		for _, token := range tokens {
+			for _, p := range idx.postings[token] {
+				matchedDocs[p.doc] = true
+			}
+		}
+		if len(matchedDocs) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + float64(len(idx.docs))/float64(len(matchedDocs)))
+		for doc := range matchedDocs {
+			if results[doc] == nil {
+				d := idx.docs[doc]
+				results[doc] = &SearchResult{Course: d.Course, Number: d.Number, Name: d.Name, Info: d.Info, words: make(map[string]bool)}
+			}
+			results[doc].MatchedTerms++
+		}
+
+		for _, token := range tokens {
+			weight := 1.0
+			if token != term {
+				weight = searchPrefixPenalty
+			}
+
+			for _, p := range idx.postings[token] {
+				results[p.doc].Score += idf * p.weight * weight
+				results[p.doc].words[token] = true
+			}
+		}
+	}
+
+	ranked := make([]SearchResult, 0, len(results))
+	for _, result := range results {
+		ranked = append(ranked, *result)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.MatchedTerms != b.MatchedTerms {
+			return a.MatchedTerms > b.MatchedTerms
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Course != b.Course {
+			return a.Course < b.Course
+		}
+		return a.Number < b.Number
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked
+}
+
+// Wraps every matched word in text between open and close, e.g. ANSI bold codes or `**`
+func (r SearchResult) Highlight(text string, open string, close string) string {
+	var b strings.Builder
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end])) {
+			end++
+		}
+
+		word := string(runes[i:end])
+		if r.words[strings.ToLower(word)] {
+			b.WriteString(open + word + close)
+		} else {
+			b.WriteString(word)
+		}
+		i = end
+	}
+
+	return b.String()
+}
+
+// The rune offset of the first word in runes that Highlight would mark, or -1. Offsets are counted in
+// runes of the original text, since lowercasing can change how many bytes a letter takes.
+func (r SearchResult) firstMatch(runes []rune) int {
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end])) {
+			end++
+		}
+
+		if r.words[strings.ToLower(string(runes[i:end]))] {
+			return i
+		}
+		i = end
+	}
+
+	return -1
+}
+
+// Shortens long info to a window starting shortly before its first matched word
+func (r SearchResult) snippet() string {
+	runes := []rune(r.Info)
+	if len(runes) <= searchSnippetLength {
+		return r.Info
+	}
+
+	start := 0
+	if first := r.firstMatch(runes); first >= 0 {
+		start = first - searchSnippetLength/4
+	}
+	start = max(0, min(start, len(runes)-searchSnippetLength))
+
+	result := string(runes[start : start+searchSnippetLength])
+	if start > 0 {
+		result = "..." + result
+	}
+	if start+searchSnippetLength < len(runes) {
+		result += "..."
+	}
+
+	return result
+}
+
+// Formats the result with its matches highlighted between open and close
+func (r SearchResult) String(open string, close string) string {
+	var result string
+	if r.Number == 0 {
+		result = fmt.Sprintf("%s (course)", r.Highlight(r.Name, open, close))
+	} else {
+		result = fmt.Sprintf("%s %d. %s", r.Course, r.Number, r.Highlight(r.Name, open, close))
+	}
+
+	if r.Info != "" {
+		result += "\n    " + r.Highlight(r.snippet(), open, close)
+	}
+
+	return result
+}
@@ -0,0 +1,82 @@
+package courseapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchIndex_Search_RanksAllTermsFirst_Success(t *testing.T) {
+	idx := NewSearchIndex(newTestTerm())
+
+	results := idx.Search("red-black trees", 0)
+
+	assert.Equal(t, "Red-Black Trees", results[0].Name)
+	assert.Equal(t, 1, results[0].Number)
+	assert.Equal(t, 3, results[0].MatchedTerms)
+	for _, result := range results[1:] {
+		assert.Less(t, result.MatchedTerms, 3)
+	}
+}
+
+func TestSearchIndex_Search_NameOutranksInfo_Success(t *testing.T) {
+	idx := NewSearchIndex(newTestTerm())
+
+	results := idx.Search("heap", 0)
+
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, "Heaps", results[0].Name)
+	assert.Equal(t, 0, results[1].Number)
+}
+
+func TestSearchIndex_Search_PrefixAndLimit_Success(t *testing.T) {
+	idx := NewSearchIndex(newTestTerm())
+
+	// "tree" matches "trees" by prefix, and the exact word "tree" in the essay ranks first
+	results := idx.Search("tree", 2)
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, "Tree of Life Essay", results[0].Name)
+
+	// Short words only match whole words
+	assert.Equal(t, 0, len(idx.Search("tr", 0)))
+	assert.Equal(t, 0, len(idx.Search("", 0)))
+	assert.Equal(t, 0, len(idx.Search("quantum", 0)))
+}
+
+func TestSearchResult_String_Highlights_Success(t *testing.T) {
+	idx := NewSearchIndex(newTestTerm())
+
+	results := idx.Search("binary heap", 1)
+
+	assert.Equal(t, "CS201 2. [Heaps]\n    Implement a [binary] [heap]; red team reviews", results[0].String("[", "]"))
+
+	results = idx.Search("hashing", 1)
+	assert.Equal(t, "CS201 (course)\n    Data structures: trees, heaps and [hashing]", results[0].String("[", "]"))
+}
+
+func TestSearchResult_String_LongInfoSnippet_Success(t *testing.T) {
+	info := strings.Repeat("filler ", 40) + "the needle is here " + strings.Repeat("filler ", 40)
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})
+	course.Assignments[0].Info = &info
+
+	results := NewSearchIndex(CourseMap{"CS101": course}).Search("needle", 0)
+	snippet := strings.Split(results[0].String("*", "*"), "\n    ")[1]
+
+	assert.True(t, strings.HasPrefix(snippet, "..."))
+	assert.True(t, strings.HasSuffix(snippet, "..."))
+	assert.Contains(t, snippet, "the *needle* is here")
+}
+
+func TestSearchResult_String_SnippetAtWholeWord_Success(t *testing.T) {
+	// needle inside another word isn't highlighted, so the snippet mustn't start there
+	info := "haystackneedle " + strings.Repeat("filler ", 40) + "the needle is here " + strings.Repeat("filler ", 40)
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})
+	course.Assignments[0].Info = &info
+
+	results := NewSearchIndex(CourseMap{"CS101": course}).Search("needle", 0)
+	snippet := strings.Split(results[0].String("*", "*"), "\n    ")[1]
+
+	assert.True(t, strings.HasPrefix(snippet, "..."))
+	assert.Contains(t, snippet, "the *needle* is here")
+}
@@ -0,0 +1,190 @@
+package courseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	InvalidSpreadsheetIDErrMsg = "not a spreadsheet ID or Google Sheets link"
+	MissingCredentialsErrMsg   = "credentials file doesn't exist"
+	ServiceAccountKeyErrMsg    = "isn't a service account key (for an OAuth client, set auth to oauth)"
+	OAuthClientKeyErrMsg       = "is a service account key, not an OAuth client (set auth to service-account)"
+	HeaderMismatchErrMsg       = "header row doesn't match"
+
+	EmptyCourseNameErrMsg    = "course name is empty"
+	MissingCourseDataErrMsg  = "course data is missing"
+	InvalidCourseDataErrMsg  = "course data isn't valid JSON"
+	CourseNameMismatchErrMsg = "course name doesn't match the name in its data"
+	DuplicateCourseRowErrMsg = "course appears in an earlier row too"
+	UndatedAssignmentErrMsg  = "assignment has no due date"
+)
+
+// Accepts a bare spreadsheet ID or a link to the spreadsheet, e.g. one copied from the browser's
+// address bar (https://docs.google.com/spreadsheets/d/<id>/edit#gid=0)
+func ParseSpreadsheetID(input string) (string, error) {
+	id := strings.TrimSpace(input)
+	if parsed, err := url.Parse(id); err == nil && parsed.Host != "" {
+		_, rest, found := strings.Cut(parsed.Path, "/d/")
+		if !found {
+			return "", fmt.Errorf("`%s`: %s", input, InvalidSpreadsheetIDErrMsg)
+		}
+		id, _, _ = strings.Cut(rest, "/")
+	}
+
+	if id == "" {
+		return "", fmt.Errorf("`%s`: %s", input, InvalidSpreadsheetIDErrMsg)
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return "", fmt.Errorf("`%s`: %s", input, InvalidSpreadsheetIDErrMsg)
+		}
+	}
+
+	return id, nil
+}
+
+// Checks the credentials file at path suits auth. Returns who it authenticates as: the service
+// account's address, or the OAuth client's ID.
+func CheckCredentials(path string, auth string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("`%s`: %s", path, MissingCredentialsErrMsg)
+	} else if err != nil {
+		return "", err
+	}
+
+	var key struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	err = json.Unmarshal(data, &key)
+	if err != nil {
+		return "", fmt.Errorf("`%s` isn't valid JSON: %v", path, err)
+	}
+
+	if auth == OAuthAuth {
+		if key.Type == "service_account" {
+			return "", fmt.Errorf("`%s` %s", path, OAuthClientKeyErrMsg)
+		}
+
+		config, err := LoadOAuthClient(path)
+		if err != nil {
+			return "", err
+		}
+		return config.ClientID, nil
+	}
+
+	if key.Type != "service_account" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return "", fmt.Errorf("`%s` %s", path, ServiceAccountKeyErrMsg)
+	}
+	return key.ClientEmail, nil
+}
+
+// Checks the first of rows, as read from a tab, is the given header
+func CheckHeader(rows [][]interface{}, header []interface{}) error {
+	var first []interface{}
+	if len(rows) > 0 {
+		first = rows[0]
+	}
+
+	if len(first) < len(header) {
+		return fmt.Errorf("%s: expected %s", HeaderMismatchErrMsg, cellsString(header))
+	}
+	for i := range header {
+		if fmt.Sprint(first[i]) != fmt.Sprint(header[i]) {
+			return fmt.Errorf("%s: expected %s, found %s", HeaderMismatchErrMsg, cellsString(header), cellsString(first[:len(header)]))
+		}
+	}
+
+	return nil
+}
+
+func cellsString(cells []interface{}) string {
+	names := make([]string, len(cells))
+	for i, cell := range cells {
+		names[i] = fmt.Sprint(cell)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Something wrong with a row of a term's tab, numbered from 1 as in Google Sheets
+type RowProblem struct {
+	Row    int
+	Course string
+	Msg    string
+}
+
+func (p RowProblem) String() string {
+	if p.Course == "" {
+		return fmt.Sprintf("row %d: %s", p.Row, p.Msg)
+	}
+
+	return fmt.Sprintf("row %d (`%s`): %s", p.Row, p.Course, p.Msg)
+}
+
+// Checks the rows of a term's tab, each a course's name in column A and its JSON in column B.
+// Blank rows are fine, since they're skipped when courses are loaded.
+func CheckCourseRows(rows [][]interface{}) []RowProblem {
+	var problems []RowProblem
+	seen := make(map[string]bool)
+
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = strings.TrimSpace(fmt.Sprint(cell))
+		}
+		for len(cells) > 0 && cells[len(cells)-1] == "" {
+			cells = cells[:len(cells)-1]
+		}
+		if len(cells) == 0 {
+			continue
+		}
+
+		problem := RowProblem{Row: i + 1, Course: cells[0]}
+		report := func(msg string) {
+			problem.Msg = msg
+			problems = append(problems, problem)
+		}
+
+		if cells[0] == "" {
+			report(EmptyCourseNameErrMsg)
+			continue
+		}
+
+		if seen[cells[0]] {
+			report(DuplicateCourseRowErrMsg)
+		}
+		seen[cells[0]] = true
+
+		if len(cells) < 2 {
+			report(MissingCourseDataErrMsg)
+			continue
+		}
+
+		var course CourseItem
+		err := json.Unmarshal([]byte(cells[1]), &course)
+		if err != nil {
+			report(fmt.Sprintf("%s: %v", InvalidCourseDataErrMsg, err))
+			continue
+		}
+
+		if course.Name != cells[0] {
+			report(fmt.Sprintf("%s (`%s`)", CourseNameMismatchErrMsg, course.Name))
+		}
+
+		for _, a := range course.Assignments {
+			if a.DueAt.IsZero() {
+				report(fmt.Sprintf("%s: `%s`", UndatedAssignmentErrMsg, a.Name))
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,190 @@
+package courseapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+)
+
+const (
+	InvalidShareRoleErrMsg  = "role must be reader or writer"
+	InvalidShareEmailErrMsg = "not a valid email address"
+	NotSharedErrMsg         = "spreadsheet isn't shared with this address"
+	ShareOwnerErrMsg        = "the spreadsheet's owner always has access"
+
+	ReaderRole = "reader"
+	WriterRole = "writer"
+	OwnerRole  = "owner"
+
+	// The role granted when none is given
+	DefaultShareRole = ReaderRole
+
+	permissionFields = "id,type,role,emailAddress,displayName"
+)
+
+// Someone the spreadsheet is shared with
+type Share struct {
+	PermissionID string
+	Email        string
+	Name         string
+	Role         string
+}
+
+func (s Share) String() string {
+	if s.Name == "" {
+		return fmt.Sprintf("%s (%s)", s.Email, s.Role)
+	}
+
+	return fmt.Sprintf("%s <%s> (%s)", s.Name, s.Email, s.Role)
+}
+
+// An address to share with and the role to give it
+type Grant struct {
+	Email string
+	Role  string
+}
+
+// Checks email is a bare address and normalizes role, defaulting to DefaultShareRole when it's empty
+func NewGrant(email string, role string) (Grant, error) {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return Grant{}, fmt.Errorf("`%s`: %s", email, InvalidShareEmailErrMsg)
+	}
+
+	role = strings.ToLower(role)
+	if role == "" {
+		role = DefaultShareRole
+	}
+	if role != ReaderRole && role != WriterRole {
+		return Grant{}, fmt.Errorf("`%s`: %s", role, InvalidShareRoleErrMsg)
+	}
+
+	return Grant{Email: email, Role: role}, nil
+}
+
+// Parses a comma-separated list of addresses, each optionally followed by :reader or :writer,
+// e.g. "alice@example.com, bob@example.com:writer"
+func ParseGrants(list string) ([]Grant, error) {
+	var grants []Grant
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		email, role, _ := strings.Cut(entry, ":")
+		grant, err := NewGrant(strings.TrimSpace(email), strings.TrimSpace(role))
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+
+	return grants, nil
+}
+
+// Manages who a spreadsheet is shared with, through the Drive permissions API
+type Sharing struct {
+	Permissions *drive.PermissionsService
+	FileID      string
+}
+
+func NewSharing(srv *drive.Service, fileID string) Sharing {
+	return Sharing{Permissions: drive.NewPermissionsService(srv), FileID: fileID}
+}
+
+// Everyone with access to the spreadsheet by address, the owner first and then alphabetically
+func (s Sharing) List(ctx context.Context) ([]Share, error) {
+	var shares []Share
+	err := s.Permissions.List(s.FileID).Fields("nextPageToken", "permissions("+permissionFields+")").Pages(ctx, func(page *drive.PermissionList) error {
+		for _, p := range page.Permissions {
+			if p.EmailAddress == "" {
+				continue
+			}
+			shares = append(shares, shareFromPermission(p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if (shares[i].Role == OwnerRole) != (shares[j].Role == OwnerRole) {
+			return shares[i].Role == OwnerRole
+		}
+		return strings.ToLower(shares[i].Email) < strings.ToLower(shares[j].Email)
+	})
+
+	return shares, nil
+}
+
+func (s Sharing) find(ctx context.Context, email string) (Share, bool, error) {
+	shares, err := s.List(ctx)
+	if err != nil {
+		return Share{}, false, err
+	}
+
+	for _, share := range shares {
+		if strings.EqualFold(share.Email, email) {
+			return share, true, nil
+		}
+	}
+
+	return Share{}, false, nil
+}
+
+// Gives grant's address access to the spreadsheet. An address that already has access has its
+// role changed instead, and notify decides whether Drive emails a newly added address.
+func (s Sharing) Share(ctx context.Context, grant Grant, notify bool) (Share, error) {
+	existing, found, err := s.find(ctx, grant.Email)
+	if err != nil {
+		return Share{}, err
+	}
+
+	if found && existing.Role == OwnerRole {
+		return Share{}, errors.New(ShareOwnerErrMsg)
+	}
+	if found && existing.Role == grant.Role {
+		return existing, nil
+	}
+
+	var p *drive.Permission
+	if found {
+		p, err = s.Permissions.Update(s.FileID, existing.PermissionID, &drive.Permission{Role: grant.Role}).Fields(permissionFields).Context(ctx).Do()
+	} else {
+		p, err = s.Permissions.Create(s.FileID, &drive.Permission{Type: "user", Role: grant.Role, EmailAddress: grant.Email}).
+			SendNotificationEmail(notify).Fields(permissionFields).Context(ctx).Do()
+	}
+	if err != nil {
+		return Share{}, err
+	}
+
+	return shareFromPermission(p), nil
+}
+
+// Takes away email's access to the spreadsheet
+func (s Sharing) Unshare(ctx context.Context, email string) error {
+	existing, found, err := s.find(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("`%s`: %s", email, NotSharedErrMsg)
+	}
+	if existing.Role == OwnerRole {
+		return errors.New(ShareOwnerErrMsg)
+	}
+
+	return s.Permissions.Delete(s.FileID, existing.PermissionID).Context(ctx).Do()
+}
+
+func shareFromPermission(p *drive.Permission) Share {
+	return Share{PermissionID: p.Id, Email: p.EmailAddress, Name: p.DisplayName, Role: p.Role}
+}
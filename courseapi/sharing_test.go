@@ -0,0 +1,174 @@
+package courseapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+// A stand-in for the Drive permissions endpoints of a single file, keeping permissions in memory
+type fakeDrive struct {
+	t           *testing.T
+	mu          sync.Mutex
+	permissions map[string]*drive.Permission
+	nextID      int
+	notified    []string
+}
+
+func newTestSharing(t *testing.T) (Sharing, *fakeDrive) {
+	fake := &fakeDrive{t: t, permissions: map[string]*drive.Permission{
+		"owner": {Id: "owner", Type: "user", Role: OwnerRole, EmailAddress: "robot@project.iam.gserviceaccount.com"},
+		"link":  {Id: "link", Type: "anyone", Role: ReaderRole},
+	}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	srv, err := drive.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(server.Client()))
+	assert.NoError(t, err)
+
+	return NewSharing(srv, "sheet"), fake
+}
+
+func (f *fakeDrive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id, hasID := strings.CutPrefix(r.URL.Path, "/files/sheet/permissions/")
+	if !hasID && r.URL.Path != "/files/sheet/permissions" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var body drive.Permission
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPatch) {
+		assert.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+	}
+
+	switch {
+	case r.Method == http.MethodGet && !hasID:
+		// Served a page at a time, to exercise paging
+		list := &drive.PermissionList{}
+		start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+		ids := make([]string, 0, len(f.permissions))
+		for id := range f.permissions {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for i, id := range ids {
+			if i >= start && i < start+2 {
+				list.Permissions = append(list.Permissions, f.permissions[id])
+			}
+		}
+		if start+2 < len(ids) {
+			list.NextPageToken = strconv.Itoa(start + 2)
+		}
+		json.NewEncoder(w).Encode(list)
+	case r.Method == http.MethodPost && !hasID:
+		f.nextID++
+		body.Id = "p" + strconv.Itoa(f.nextID)
+		f.permissions[body.Id] = &body
+		if r.URL.Query().Get("sendNotificationEmail") != "false" {
+			f.notified = append(f.notified, body.EmailAddress)
+		}
+		json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodPatch && f.permissions[id] != nil:
+		f.permissions[id].Role = body.Role
+		json.NewEncoder(w).Encode(f.permissions[id])
+	case r.Method == http.MethodDelete && f.permissions[id] != nil:
+		delete(f.permissions, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, `{"error": {"code": 404, "message": "Permission not found"}}`, http.StatusNotFound)
+	}
+}
+
+func TestParseGrants_Success(t *testing.T) {
+	grants, err := ParseGrants(" alice@example.com, bob@example.com:Writer,,carol@example.com:reader ")
+	assert.NoError(t, err)
+	assert.Equal(t, []Grant{
+		{Email: "alice@example.com", Role: ReaderRole},
+		{Email: "bob@example.com", Role: WriterRole},
+		{Email: "carol@example.com", Role: ReaderRole},
+	}, grants)
+
+	grants, err = ParseGrants("")
+	assert.NoError(t, err)
+	assert.Empty(t, grants)
+}
+
+func TestParseGrants_Failure(t *testing.T) {
+	_, err := ParseGrants("alice@example.com:owner")
+	assert.EqualError(t, err, "`owner`: "+InvalidShareRoleErrMsg)
+
+	for _, email := range []string{"alice", "Alice <alice@example.com>", "@example.com"} {
+		_, err = NewGrant(email, "")
+		assert.EqualError(t, err, "`"+email+"`: "+InvalidShareEmailErrMsg, email)
+	}
+}
+
+func TestSharing_Share_Success(t *testing.T) {
+	sharing, fake := newTestSharing(t)
+	ctx := context.Background()
+
+	share, err := sharing.Share(ctx, Grant{Email: "bob@example.com", Role: WriterRole}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob@example.com (writer)", share.String())
+
+	_, err = sharing.Share(ctx, Grant{Email: "alice@example.com", Role: ReaderRole}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob@example.com"}, fake.notified)
+
+	// Sharing again changes the role rather than adding the address twice
+	share, err = sharing.Share(ctx, Grant{Email: "Bob@example.com", Role: ReaderRole}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, ReaderRole, share.Role)
+
+	shares, err := sharing.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(shares))
+	assert.Equal(t, OwnerRole, shares[0].Role)
+	assert.Equal(t, "alice@example.com", shares[1].Email)
+	assert.Equal(t, "bob@example.com (reader)", shares[2].String())
+	assert.Equal(t, []string{"bob@example.com"}, fake.notified)
+
+	_, err = sharing.Share(ctx, Grant{Email: "robot@project.iam.gserviceaccount.com", Role: ReaderRole}, true)
+	assert.EqualError(t, err, ShareOwnerErrMsg)
+}
+
+func TestSharing_Unshare_Success(t *testing.T) {
+	sharing, _ := newTestSharing(t)
+	ctx := context.Background()
+
+	_, err := sharing.Share(ctx, Grant{Email: "bob@example.com", Role: WriterRole}, true)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sharing.Unshare(ctx, "bob@example.com"))
+	shares, err := sharing.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(shares))
+}
+
+func TestSharing_Unshare_Failure(t *testing.T) {
+	sharing, _ := newTestSharing(t)
+	ctx := context.Background()
+
+	err := sharing.Unshare(ctx, "bob@example.com")
+	assert.EqualError(t, err, "`bob@example.com`: "+NotSharedErrMsg)
+
+	err = sharing.Unshare(ctx, "robot@project.iam.gserviceaccount.com")
+	assert.EqualError(t, err, ShareOwnerErrMsg)
+
+	missing := Sharing{Permissions: sharing.Permissions, FileID: "other"}
+	_, err = missing.List(ctx)
+	assert.Error(t, err)
+}
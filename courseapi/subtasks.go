@@ -0,0 +1,149 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	InvalidSubtaskIndexErrMsg = "no subtask at that number"
+	EmptySubtaskNameErrMsg    = "subtask name can't be empty"
+	SubtaskDueAfterErrMsg     = "subtask can't be due after its assignment"
+)
+
+// A checklist item within an assignment, e.g. a project's proposal or draft
+type Subtask struct {
+	Name  string     `json:"name"`
+	DueAt *time.Time `json:"due_at,omitempty"`
+	Done  bool       `json:"done,omitempty"`
+}
+
+func (s Subtask) DeepCopy() Subtask {
+	cpy := s
+
+	if s.DueAt != nil {
+		dueCopy := *s.DueAt
+		cpy.DueAt = &dueCopy
+	}
+
+	return cpy
+}
+
+func (s Subtask) String() string {
+	check := "[ ]"
+	if s.Done {
+		check = "[x]"
+	}
+
+	due := ""
+	if s.DueAt != nil {
+		due = fmt.Sprintf(" (due %s)", s.DueAt.Format(DateFormat))
+	}
+
+	return fmt.Sprintf("%s %s%s", check, s.Name, due)
+}
+
+func (a AssignmentItem) subtasksDone() int {
+	done := 0
+	for _, s := range a.Subtasks {
+		if s.Done {
+			done++
+		}
+	}
+
+	return done
+}
+
+// Percentage of subtasks done. ok is false when the assignment has no subtasks.
+func (a AssignmentItem) Progress() (percent float64, ok bool) {
+	if len(a.Subtasks) == 0 {
+		return 0, false
+	}
+
+	return float64(a.subtasksDone()) / float64(len(a.Subtasks)) * 100, true
+}
+
+func (a AssignmentItem) subtasksString() string {
+	percent, ok := a.Progress()
+	if !ok {
+		return ""
+	}
+
+	result := fmt.Sprintf("\nProgress: %d/%d (%.0f%%)", a.subtasksDone(), len(a.Subtasks), percent)
+	for i, s := range a.Subtasks {
+		result += fmt.Sprintf("\n  %d. %s", i+1, s.String())
+	}
+
+	return result
+}
+
+func (c *CourseItem) assignment(index int) (*AssignmentItem, error) {
+	if index < 0 || index >= len(c.Assignments) {
+		return nil, errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	return &c.Assignments[index], nil
+}
+
+// Appends a subtask to the assignment at index, optionally due on a date (in DateFormat) no later than the assignment
+func (c *CourseItem) AddSubtask(index int, name string, due ...string) error {
+	a, err := c.assignment(index)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return errors.New(EmptySubtaskNameErrMsg)
+	}
+
+	subtask := Subtask{Name: name}
+	if len(due) > 1 {
+		return errors.New(TooManyParamsErrMsg)
+	} else if len(due) == 1 {
+		dueDate, err := time.Parse(DateFormat, due[0])
+		if err != nil {
+			return invalidDateError()
+		}
+
+		if dueDate.After(a.DueAt) {
+			return errors.New(SubtaskDueAfterErrMsg)
+		}
+		subtask.DueAt = &dueDate
+	}
+
+	a.Subtasks = append(a.Subtasks, subtask)
+	return nil
+}
+
+func (c *CourseItem) RemoveSubtask(index int, subtask int) error {
+	a, err := c.assignment(index)
+	if err != nil {
+		return err
+	}
+
+	if subtask < 0 || subtask >= len(a.Subtasks) {
+		return errors.New(InvalidSubtaskIndexErrMsg)
+	}
+
+	a.Subtasks = append(a.Subtasks[:subtask], a.Subtasks[subtask+1:]...)
+	if len(a.Subtasks) == 0 {
+		a.Subtasks = nil
+	}
+	return nil
+}
+
+// Checks off a subtask, or unchecks it when done is false
+func (c *CourseItem) SetSubtaskDone(index int, subtask int, done bool) error {
+	a, err := c.assignment(index)
+	if err != nil {
+		return err
+	}
+
+	if subtask < 0 || subtask >= len(a.Subtasks) {
+		return errors.New(InvalidSubtaskIndexErrMsg)
+	}
+
+	a.Subtasks[subtask].Done = done
+	return nil
+}
@@ -0,0 +1,86 @@
+package courseapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCourseItem_AddSubtask_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Project", "04/30/25"})
+	course.AddSubtask(0, "Proposal", "02/15/25")
+	course.AddSubtask(0, "Draft", "03/30/25")
+	course.AddSubtask(0, "Final")
+
+	subtasks := course.Assignments[0].Subtasks
+	assert.Equal(t, 3, len(subtasks))
+	assert.Equal(t, "02/15/25", subtasks[0].DueAt.Format(DateFormat))
+	assert.Nil(t, subtasks[2].DueAt)
+}
+
+func TestCourseItem_AddSubtask_Failure(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Project", "04/30/25"})
+
+	assert.EqualError(t, course.AddSubtask(0, "Late", "05/01/25"), SubtaskDueAfterErrMsg)
+	assert.EqualError(t, course.AddSubtask(0, "Draft", "March"), invalidDateError().Error())
+	assert.EqualError(t, course.AddSubtask(0, ""), EmptySubtaskNameErrMsg)
+	assert.EqualError(t, course.AddSubtask(1, "Draft"), InvalidSliceRemoveErrMsg)
+	assert.Nil(t, course.Assignments[0].Subtasks)
+}
+
+func TestCourseItem_SetSubtaskDone_Progress_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Project", "04/30/25"})
+	course.AddSubtask(0, "Proposal", "02/15/25")
+	course.AddSubtask(0, "Draft", "03/30/25")
+	course.AddSubtask(0, "Final")
+
+	_, ok := AssignmentItem{}.Progress()
+	assert.False(t, ok)
+
+	assert.NoError(t, course.SetSubtaskDone(0, 0, true))
+	percent, ok := course.Assignments[0].Progress()
+	assert.True(t, ok)
+	assert.InDelta(t, 33.33, percent, 0.01)
+
+	assert.Equal(t, "Project\nDue: 04/30/25\nProgress: 1/3 (33%)\n  1. [x] Proposal (due 02/15/25)\n  2. [ ] Draft (due 03/30/25)\n  3. [ ] Final",
+		course.Assignments[0].String())
+
+	assert.NoError(t, course.SetSubtaskDone(0, 0, false))
+	percent, _ = course.Assignments[0].Progress()
+	assert.Equal(t, 0.0, percent)
+
+	assert.EqualError(t, course.SetSubtaskDone(0, 3, true), InvalidSubtaskIndexErrMsg)
+}
+
+func TestCourseItem_RemoveSubtask_DeepCopied_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Project", "04/30/25"})
+	course.AddSubtask(0, "Proposal", "02/15/25")
+	course.AddSubtask(0, "Draft", "03/30/25")
+	course.AddSubtask(0, "Final")
+
+	copy := course.DeepCopy()
+	copy.SetSubtaskDone(0, 2, true)
+	assert.NoError(t, copy.RemoveSubtask(0, 0))
+
+	assert.Equal(t, "Draft", copy.Assignments[0].Subtasks[0].Name)
+	assert.Equal(t, 3, len(course.Assignments[0].Subtasks))
+	assert.False(t, course.Assignments[0].Subtasks[2].Done)
+
+	assert.EqualError(t, copy.RemoveSubtask(0, 5), InvalidSubtaskIndexErrMsg)
+}
+
+func TestReplaceAssignment_SubtaskDueAfter_Failure(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Project", "04/30/25"})
+	course.AddSubtask(0, "Proposal", "02/15/25")
+	course.AddSubtask(0, "Draft", "03/30/25")
+	course.AddSubtask(0, "Final")
+
+	_, err := course.Assignments.ReplaceAssignment(0, "Project", "03/15/25")
+	assert.EqualError(t, err, SubtaskDueAfterErrMsg+": `Draft` is due 03/30/25")
+	assert.Equal(t, "04/30/25", course.Assignments[0].DueAt.Format(DateFormat))
+
+	// Moving the deadline up to the last subtask, or later, is fine
+	_, err = course.Assignments.ReplaceAssignment(0, "Project", "03/30/25")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(course.Assignments[0].Subtasks))
+}
@@ -0,0 +1,120 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	InvalidTagErrMsg  = "tags must be a single word without commas"
+	TagExistsErrMsg   = "assignment already has this tag"
+	TagNotFoundErrMsg = "assignment doesn't have this tag"
+)
+
+// Tags are compared case-insensitively, so they are stored lowercase
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || strings.ContainsAny(tag, ", \t") {
+		return "", errors.New(InvalidTagErrMsg)
+	}
+
+	return tag, nil
+}
+
+func (a AssignmentItem) HasTag(tag string) bool {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range a.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adds tags to the assignment at index, keeping its tags sorted. Nothing is added if any tag is
+// invalid or already present.
+func (c *CourseItem) AddTags(index int, tags ...string) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	a := &c.Assignments[index]
+	added := append([]string{}, a.Tags...)
+	for _, tag := range tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return fmt.Errorf("%s: `%s`", InvalidTagErrMsg, tag)
+		}
+
+		for _, existing := range added {
+			if existing == normalized {
+				return fmt.Errorf("%s: `%s`", TagExistsErrMsg, normalized)
+			}
+		}
+		added = append(added, normalized)
+	}
+
+	sort.Strings(added)
+	a.Tags = added
+	return nil
+}
+
+func (c *CourseItem) RemoveTag(index int, tag string) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	normalized, _ := NormalizeTag(tag)
+
+	a := &c.Assignments[index]
+	for i, t := range a.Tags {
+		if t == normalized {
+			a.Tags = append(a.Tags[:i], a.Tags[i+1:]...)
+			if len(a.Tags) == 0 {
+				a.Tags = nil
+			}
+			return nil
+		}
+	}
+
+	return errors.New(TagNotFoundErrMsg)
+}
+
+// How many of the course's assignments carry each tag
+func (c CourseItem) TagCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, a := range c.Assignments {
+		for _, tag := range a.Tags {
+			counts[tag]++
+		}
+	}
+
+	return counts
+}
+
+// Formats tag counts as `exam (2), lab (4)`, sorted by tag
+func TagCountsString(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "No tags."
+	}
+
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	parts := make([]string, len(tags))
+	for i, tag := range tags {
+		parts[i] = fmt.Sprintf("%s (%d)", tag, counts[tag])
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,64 @@
+package courseapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTag_Success(t *testing.T) {
+	tag, err := NormalizeTag("  Exam ")
+	assert.NoError(t, err)
+	assert.Equal(t, "exam", tag)
+
+	_, err = NormalizeTag("group project")
+	assert.EqualError(t, err, InvalidTagErrMsg)
+
+	_, err = NormalizeTag("a,b")
+	assert.EqualError(t, err, InvalidTagErrMsg)
+}
+
+func TestCourseItem_AddTags_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})
+
+	assert.NoError(t, course.AddTags(0, "Lab", "group"))
+
+	assert.Equal(t, []string{"group", "lab"}, course.Assignments[0].Tags)
+	assert.True(t, course.Assignments[0].HasTag("LAB"))
+	assert.Contains(t, course.Assignments[0].String(), "\nTags: group, lab")
+}
+
+func TestCourseItem_AddTags_Failure(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})
+	course.AddTags(0, "lab")
+
+	assert.ErrorContains(t, course.AddTags(0, "exam", "lab"), TagExistsErrMsg)
+	assert.ErrorContains(t, course.AddTags(0, "exam", "exam"), TagExistsErrMsg)
+	assert.ErrorContains(t, course.AddTags(0, "bad tag"), InvalidTagErrMsg)
+	assert.EqualError(t, course.AddTags(1, "exam"), InvalidSliceRemoveErrMsg)
+
+	assert.Equal(t, []string{"lab"}, course.Assignments[0].Tags)
+}
+
+func TestCourseItem_RemoveTag_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"})
+	course.AddTags(0, "lab")
+
+	copy := course.DeepCopy()
+	assert.NoError(t, copy.RemoveTag(0, "Lab"))
+	assert.Nil(t, copy.Assignments[0].Tags)
+	assert.Equal(t, []string{"lab"}, course.Assignments[0].Tags)
+
+	assert.EqualError(t, copy.RemoveTag(0, "lab"), TagNotFoundErrMsg)
+}
+
+func TestCourseItem_TagCounts_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/02/25"}, [2]string{"Lab 2", "02/09/25"}, [2]string{"Midterm", "03/01/25"})
+	course.AddTags(0, "lab")
+	course.AddTags(1, "lab", "group")
+	course.AddTags(2, "exam")
+
+	assert.Equal(t, map[string]int{"lab": 2, "group": 1, "exam": 1}, course.TagCounts())
+	assert.Equal(t, "exam (1), group (1), lab (2)", TagCountsString(course.TagCounts()))
+	assert.Equal(t, "No tags.", TagCountsString(map[string]int{}))
+}
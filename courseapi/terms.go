@@ -0,0 +1,136 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	EmptyTermNameErrMsg    = "term name can't be empty"
+	TermNameTooLongErrMsg  = "term name can't be longer than 100 characters"
+	TermExistsErrMsg       = "term already exists"
+	TermNotFoundErrMsg     = "term doesn't exist"
+	TermAlreadyArchivedMsg = "term is already archived"
+	TermNotArchivedErrMsg  = "term isn't archived"
+
+	maxTermNameLength = 100
+)
+
+// A group of courses such as "Fall 2026", stored in its own sheet tab named after it
+type Term struct {
+	Name     string
+	Archived bool
+}
+
+func ValidateTermName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New(EmptyTermNameErrMsg)
+	}
+
+	if len(name) > maxTermNameLength {
+		return errors.New(TermNameTooLongErrMsg)
+	}
+
+	return nil
+}
+
+type TermList []Term
+
+// Parses term rows of `name, archived` as stored in the terms tab, skipping blank rows
+func TermListFromRows(rows [][]interface{}) TermList {
+	var terms TermList
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+
+		name := fmt.Sprint(row[0])
+		if name == "" {
+			continue
+		}
+
+		archived := len(row) > 1 && strings.EqualFold(fmt.Sprint(row[1]), "true")
+		terms = append(terms, Term{Name: name, Archived: archived})
+	}
+
+	return terms
+}
+
+func (l TermList) Rows() [][]interface{} {
+	rows := make([][]interface{}, len(l))
+	for i, term := range l {
+		rows[i] = []interface{}{term.Name, term.Archived}
+	}
+
+	return rows
+}
+
+func (l TermList) Find(name string) (Term, bool) {
+	for _, term := range l {
+		if term.Name == name {
+			return term, true
+		}
+	}
+
+	return Term{}, false
+}
+
+func (l *TermList) Add(name string) error {
+	if err := ValidateTermName(name); err != nil {
+		return err
+	}
+
+	if _, exists := l.Find(name); exists {
+		return errors.New(TermExistsErrMsg)
+	}
+
+	*l = append(*l, Term{Name: name})
+	return nil
+}
+
+func (l TermList) SetArchived(name string, archived bool) error {
+	for i := range l {
+		if l[i].Name != name {
+			continue
+		}
+
+		if archived && l[i].Archived {
+			return errors.New(TermAlreadyArchivedMsg)
+		} else if !archived && !l[i].Archived {
+			return errors.New(TermNotArchivedErrMsg)
+		}
+
+		l[i].Archived = archived
+		return nil
+	}
+
+	return errors.New(TermNotFoundErrMsg)
+}
+
+func (l TermList) String(current string, includeArchived bool) string {
+	result := ""
+	for _, term := range l {
+		if term.Archived && !includeArchived {
+			continue
+		}
+
+		marker := "  "
+		if term.Name == current {
+			marker = "* "
+		}
+
+		archived := ""
+		if term.Archived {
+			archived = " (archived)"
+		}
+
+		result += fmt.Sprintf("%s%s%s\n", marker, term.Name, archived)
+	}
+
+	if result == "" {
+		return "No terms available.\n"
+	}
+
+	return result
+}
@@ -0,0 +1,48 @@
+package courseapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermListFromRows_RoundTrip_Success(t *testing.T) {
+	rows := [][]interface{}{{"Fall 2025", "TRUE"}, {}, {"Spring 2026"}, {"Fall 2026", false}}
+
+	terms := TermListFromRows(rows)
+
+	assert.Equal(t, TermList{{"Fall 2025", true}, {"Spring 2026", false}, {"Fall 2026", false}}, terms)
+	assert.Equal(t, []interface{}{"Fall 2025", true}, terms.Rows()[0])
+}
+
+func TestTermList_Add_Duplicate_Failure(t *testing.T) {
+	terms := TermList{}
+
+	assert.NoError(t, terms.Add("Fall 2026"))
+	assert.EqualError(t, terms.Add("Fall 2026"), TermExistsErrMsg)
+	assert.EqualError(t, terms.Add("  "), EmptyTermNameErrMsg)
+	assert.EqualError(t, terms.Add(strings.Repeat("x", 101)), TermNameTooLongErrMsg)
+	assert.Equal(t, 1, len(terms))
+}
+
+func TestTermList_SetArchived_Success(t *testing.T) {
+	terms := TermList{{Name: "Fall 2025"}}
+
+	assert.NoError(t, terms.SetArchived("Fall 2025", true))
+	assert.True(t, terms[0].Archived)
+	assert.EqualError(t, terms.SetArchived("Fall 2025", true), TermAlreadyArchivedMsg)
+
+	assert.NoError(t, terms.SetArchived("Fall 2025", false))
+	assert.EqualError(t, terms.SetArchived("Fall 2025", false), TermNotArchivedErrMsg)
+
+	assert.EqualError(t, terms.SetArchived("Spring 2030", true), TermNotFoundErrMsg)
+}
+
+func TestTermList_String_HidesArchived_Success(t *testing.T) {
+	terms := TermList{{"Fall 2025", true}, {"Spring 2026", false}}
+
+	assert.Equal(t, "* Spring 2026\n", terms.String("Spring 2026", false))
+	assert.Equal(t, "  Fall 2025 (archived)\n* Spring 2026\n", terms.String("Spring 2026", true))
+	assert.Equal(t, "No terms available.\n", TermList{}.String("", false))
+}
@@ -0,0 +1,203 @@
+package courseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	NothingToUndoErrMsg = "nothing to undo"
+	NothingToRedoErrMsg = "nothing to redo"
+
+	// Default number of mutations kept for undo
+	DefaultUndoLimit = 50
+)
+
+// The state of one course before and after a mutation. A nil Before means the course
+// was created, and a nil After means it was removed.
+type CourseSnapshot struct {
+	Name   string      `json:"name"`
+	Before *CourseItem `json:"before,omitempty"`
+	After  *CourseItem `json:"after,omitempty"`
+}
+
+// Swaps Before and After, giving the snapshot that reverses this one
+func (s CourseSnapshot) Inverse() CourseSnapshot {
+	return CourseSnapshot{Name: s.Name, Before: s.After, After: s.Before}
+}
+
+// A single user command and every course it changed
+type Mutation struct {
+	Command string           `json:"command"`
+	At      time.Time        `json:"at"`
+	Changes []CourseSnapshot `json:"changes"`
+}
+
+func NewMutation(command string, changes ...CourseSnapshot) Mutation {
+	return Mutation{Command: command, At: time.Now(), Changes: changes}
+}
+
+// Reverses the mutation; changes are applied in reverse order so multi-course edits unwind cleanly
+func (m Mutation) Inverse() Mutation {
+	inverse := Mutation{Command: m.Command, At: m.At, Changes: make([]CourseSnapshot, len(m.Changes))}
+	for i, change := range m.Changes {
+		inverse.Changes[len(m.Changes)-1-i] = change.Inverse()
+	}
+
+	return inverse
+}
+
+// Undo and redo stacks of mutations, safe for use from multiple goroutines
+type UndoHistory struct {
+	mu    sync.Mutex
+	limit int
+	undo  []Mutation
+	redo  []Mutation
+}
+
+type undoHistoryJSON struct {
+	Undo []Mutation `json:"undo"`
+	Redo []Mutation `json:"redo"`
+}
+
+func NewUndoHistory(limit int) *UndoHistory {
+	if limit <= 0 {
+		limit = DefaultUndoLimit
+	}
+
+	return &UndoHistory{limit: limit}
+}
+
+// Records a new mutation, dropping the oldest once the limit is reached. Any redo is discarded,
+// since it no longer applies on top of the new state.
+func (h *UndoHistory) Record(m Mutation) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.undo = append(h.undo, m)
+	if len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+	h.redo = nil
+}
+
+// Returns the most recent mutation to undo without removing it, so it can be kept if undoing fails
+func (h *UndoHistory) PeekUndo() (Mutation, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.undo) == 0 {
+		return Mutation{}, errors.New(NothingToUndoErrMsg)
+	}
+
+	return h.undo[len(h.undo)-1], nil
+}
+
+func (h *UndoHistory) PeekRedo() (Mutation, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.redo) == 0 {
+		return Mutation{}, errors.New(NothingToRedoErrMsg)
+	}
+
+	return h.redo[len(h.redo)-1], nil
+}
+
+// Moves the most recent mutation from the undo stack to the redo stack, once it has been undone
+func (h *UndoHistory) Undo() (Mutation, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.undo) == 0 {
+		return Mutation{}, errors.New(NothingToUndoErrMsg)
+	}
+
+	m := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, m)
+	return m, nil
+}
+
+// Moves the most recently undone mutation back onto the undo stack, once it has been redone
+func (h *UndoHistory) Redo() (Mutation, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.redo) == 0 {
+		return Mutation{}, errors.New(NothingToRedoErrMsg)
+	}
+
+	m := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, m)
+	return m, nil
+}
+
+// Forgets everything, e.g. after a refresh brings in remote changes that make the recorded snapshots stale
+func (h *UndoHistory) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.undo, h.redo = nil, nil
+}
+
+func (h *UndoHistory) Len() (int, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.undo), len(h.redo)
+}
+
+// The undo histories of every spreadsheet and tab sharing one file, by SheetScope, so undo never
+// applies snapshots to a different spreadsheet or term than they were taken from
+type UndoHistoryFile struct {
+	histories map[string]undoHistoryJSON
+}
+
+func NewUndoHistoryFile() UndoHistoryFile {
+	return UndoHistoryFile{histories: make(map[string]undoHistoryJSON)}
+}
+
+func ReadUndoHistoryFile(r io.Reader) (UndoHistoryFile, error) {
+	f := NewUndoHistoryFile()
+	err := json.NewDecoder(r).Decode(&f.histories)
+	if err != nil {
+		return UndoHistoryFile{}, err
+	}
+
+	return f, nil
+}
+
+func (f UndoHistoryFile) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(f.histories)
+}
+
+// The history stored for scope, or an empty one if there is none
+func (f UndoHistoryFile) History(scope string, limit int) *UndoHistory {
+	stored := f.histories[scope]
+
+	h := NewUndoHistory(limit)
+	h.undo, h.redo = stored.Undo, stored.Redo
+	if len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+
+	return h
+}
+
+// Stores h as the history for scope, leaving other scopes as they are
+func (f UndoHistoryFile) Put(scope string, h *UndoHistory) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.undo) == 0 && len(h.redo) == 0 {
+		delete(f.histories, scope)
+		return
+	}
+
+	f.histories[scope] = undoHistoryJSON{Undo: h.undo, Redo: h.redo}
+}
@@ -0,0 +1,122 @@
+package courseapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutation_Inverse_ReversesChanges_Success(t *testing.T) {
+	before := CourseItem{Name: "CS101"}
+	after := CourseItem{Name: "CS101", Assignments: AssignmentList{}}
+	after.Assignments.AddAssignment("Lab 1", "02/02/25")
+	created := CourseItem{Name: "MATH100"}
+
+	m := NewMutation("import-csv",
+		CourseSnapshot{Name: "CS101", Before: &before, After: &after},
+		CourseSnapshot{Name: "MATH100", After: &created},
+	)
+	inverse := m.Inverse()
+
+	assert.Equal(t, "MATH100", inverse.Changes[0].Name)
+	assert.Equal(t, &created, inverse.Changes[0].Before)
+	assert.Nil(t, inverse.Changes[0].After)
+	assert.Equal(t, &after, inverse.Changes[1].Before)
+	assert.Equal(t, &before, inverse.Changes[1].After)
+}
+
+func TestUndoHistory_UndoRedo_Success(t *testing.T) {
+	h := NewUndoHistory(0)
+	h.Record(NewMutation("create-course"))
+	h.Record(NewMutation("create-assignment"))
+
+	m, err := h.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, "create-assignment", m.Command)
+
+	m, err = h.Redo()
+	assert.NoError(t, err)
+	assert.Equal(t, "create-assignment", m.Command)
+
+	undos, redos := h.Len()
+	assert.Equal(t, 2, undos)
+	assert.Equal(t, 0, redos)
+}
+
+func TestUndoHistory_Empty_Failure(t *testing.T) {
+	h := NewUndoHistory(0)
+
+	_, err := h.Undo()
+	assert.EqualError(t, err, NothingToUndoErrMsg)
+
+	_, err = h.PeekRedo()
+	assert.EqualError(t, err, NothingToRedoErrMsg)
+}
+
+func TestUndoHistory_RecordClearsRedo_Success(t *testing.T) {
+	h := NewUndoHistory(0)
+	h.Record(NewMutation("create-course"))
+	h.Undo()
+
+	h.Record(NewMutation("remove-course"))
+
+	_, err := h.Redo()
+	assert.EqualError(t, err, NothingToRedoErrMsg)
+}
+
+func TestUndoHistory_Limit_DropsOldest_Success(t *testing.T) {
+	h := NewUndoHistory(2)
+	h.Record(NewMutation("first"))
+	h.Record(NewMutation("second"))
+	h.Record(NewMutation("third"))
+
+	undos, _ := h.Len()
+	assert.Equal(t, 2, undos)
+
+	h.Undo()
+	m, _ := h.Undo()
+	assert.Equal(t, "second", m.Command)
+}
+
+func TestUndoHistoryFile_RoundTrip_Success(t *testing.T) {
+	after := CourseItem{Name: "CS101", Assignments: AssignmentList{}}
+	h := NewUndoHistory(0)
+	h.Record(NewMutation("create-course", CourseSnapshot{Name: "CS101", After: &after}))
+	h.Record(NewMutation("remove-course", CourseSnapshot{Name: "CS101", Before: &after}))
+	h.Undo()
+
+	other := NewUndoHistory(0)
+	other.Record(NewMutation("create-course", CourseSnapshot{Name: "MATH200", After: &CourseItem{Name: "MATH200"}}))
+
+	file := NewUndoHistoryFile()
+	file.Put(SheetScope("sheet", "Fall 2026"), h)
+	file.Put(SheetScope("sheet", "Spring 2027"), other)
+	file.Put(SheetScope("other-sheet", "Fall 2026"), NewUndoHistory(0))
+
+	var buf bytes.Buffer
+	assert.NoError(t, file.Write(&buf))
+
+	read, err := ReadUndoHistoryFile(&buf)
+	assert.NoError(t, err)
+	loaded := read.History(SheetScope("sheet", "Fall 2026"), 0)
+
+	undos, redos := loaded.Len()
+	assert.Equal(t, 1, undos)
+	assert.Equal(t, 1, redos)
+
+	m, _ := loaded.PeekUndo()
+	assert.Equal(t, "CS101", m.Changes[0].After.Name)
+	assert.Nil(t, m.Changes[0].Before)
+
+	// Each spreadsheet and tab only sees its own history
+	m, _ = read.History(SheetScope("sheet", "Spring 2027"), 0).PeekUndo()
+	assert.Equal(t, "MATH200", m.Changes[0].Name)
+
+	undos, redos = read.History(SheetScope("other-sheet", "Fall 2026"), 0).Len()
+	assert.Equal(t, 0, undos+redos)
+
+	_, err = ReadUndoHistoryFile(strings.NewReader(`{"undo": [], "redo": []}`))
+	assert.Error(t, err)
+}
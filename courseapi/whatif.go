@@ -0,0 +1,210 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	InvalidCutoffErrMsg        = "grade cutoffs must look like A=90 with a minimum between 0 and 100"
+	DuplicateCutoffErrMsg      = "grade cutoffs repeat a letter or minimum"
+	UnknownLetterErrMsg        = "letter grade isn't one of the course's cutoffs"
+	AlreadyGradedErrMsg        = "assignment is already graded"
+	UngradableAssignmentErrMsg = "assignment can't count toward the grade (set its points, and its category if the course has categories)"
+	InvalidAssumePercentErrMsg = "assumed percentage can't be negative"
+)
+
+// The minimum percentage needed to earn a letter grade
+type LetterCutoff struct {
+	Letter string  `json:"letter"`
+	Min    float64 `json:"min"`
+}
+
+// Used when a course doesn't set its own cutoffs
+var DefaultGradeCutoffs = []LetterCutoff{
+	{Letter: "A", Min: 90},
+	{Letter: "B", Min: 80},
+	{Letter: "C", Min: 70},
+	{Letter: "D", Min: 60},
+	{Letter: "F", Min: 0},
+}
+
+// Parses cutoffs such as `A=93 A-=90 B+=87`, sorted from the highest minimum down
+func ParseGradeCutoffs(args []string) ([]LetterCutoff, error) {
+	cutoffs := make([]LetterCutoff, 0, len(args))
+	seenLetters := make(map[string]bool)
+	seenMins := make(map[float64]bool)
+
+	for _, arg := range args {
+		letter, minStr, found := strings.Cut(arg, "=")
+		min, err := strconv.ParseFloat(minStr, 64)
+		if !found || letter == "" || err != nil || !isFinite(min) || min < 0 || min > 100 {
+			return nil, fmt.Errorf("%s: `%s`", InvalidCutoffErrMsg, arg)
+		}
+
+		if seenLetters[letter] || seenMins[min] {
+			return nil, errors.New(DuplicateCutoffErrMsg)
+		}
+		seenLetters[letter], seenMins[min] = true, true
+
+		cutoffs = append(cutoffs, LetterCutoff{Letter: letter, Min: min})
+	}
+
+	sort.Slice(cutoffs, func(i, j int) bool {
+		return cutoffs[i].Min > cutoffs[j].Min
+	})
+
+	return cutoffs, nil
+}
+
+// The course's letter grade cutoffs, highest first
+func (c CourseItem) Cutoffs() []LetterCutoff {
+	if len(c.GradeCutoffs) == 0 {
+		return DefaultGradeCutoffs
+	}
+
+	return c.GradeCutoffs
+}
+
+// The letter earned with percent, or "" if it falls below every cutoff
+func (c CourseItem) LetterFor(percent float64) string {
+	for _, cutoff := range c.Cutoffs() {
+		if percent >= cutoff.Min {
+			return cutoff.Letter
+		}
+	}
+
+	return ""
+}
+
+func (c CourseItem) cutoffFor(letter string) (LetterCutoff, error) {
+	for _, cutoff := range c.Cutoffs() {
+		if cutoff.Letter == letter {
+			return cutoff, nil
+		}
+	}
+
+	return LetterCutoff{}, errors.New(UnknownLetterErrMsg)
+}
+
+func (c CourseItem) countsTowardGrade(a AssignmentItem) bool {
+	if a.PointsPossible == nil {
+		return false
+	}
+
+	if len(c.Categories) == 0 {
+		return true
+	}
+
+	if a.Category == nil {
+		return false
+	}
+
+	_, exists := c.category(*a.Category)
+	return exists
+}
+
+// Projects the grade as if each ungraded assignment had the hypothetical score given for its index.
+// Ungraded assignments without a hypothetical score get assumePercent of their points, if given,
+// or are otherwise left out as they are today.
+func (c CourseItem) WhatIf(scores map[int]float64, assumePercent *float64) (CourseGrade, error) {
+	if assumePercent != nil && *assumePercent < 0 {
+		return CourseGrade{}, errors.New(InvalidAssumePercentErrMsg)
+	}
+
+	projected := c.DeepCopy()
+
+	for index, earned := range scores {
+		if index < 0 || index >= len(projected.Assignments) {
+			return CourseGrade{}, errors.New(InvalidSliceRemoveErrMsg)
+		}
+
+		if projected.Assignments[index].IsGraded() {
+			return CourseGrade{}, fmt.Errorf("assignment number %d: %s", index+1, AlreadyGradedErrMsg)
+		}
+
+		err := projected.RecordScore(index, earned)
+		if err != nil {
+			return CourseGrade{}, fmt.Errorf("assignment number %d: %v", index+1, err)
+		}
+	}
+
+	if assumePercent != nil {
+		for i, a := range projected.Assignments {
+			if !a.IsGraded() && a.PointsPossible != nil {
+				projected.RecordScore(i, *a.PointsPossible*(*assumePercent)/100)
+			}
+		}
+	}
+
+	return projected.Grade()
+}
+
+type RequiredScore struct {
+	Letter   string
+	Points   float64
+	Possible float64
+}
+
+// Whether the letter is already guaranteed no matter the score
+func (r RequiredScore) Secured() bool {
+	return r.Points <= 0
+}
+
+// Whether the letter can be reached without extra credit
+func (r RequiredScore) Reachable() bool {
+	return r.Points <= r.Possible
+}
+
+func (r RequiredScore) String() string {
+	switch {
+	case r.Secured():
+		return fmt.Sprintf("%s is secured regardless of this score.", r.Letter)
+	case !r.Reachable():
+		return fmt.Sprintf("%s would need %.2f/%g (%.2f%%), which isn't reachable without extra credit.", r.Letter, r.Points, r.Possible, r.Points/r.Possible*100)
+	default:
+		return fmt.Sprintf("%s needs at least %.2f/%g (%.2f%%).", r.Letter, r.Points, r.Possible, r.Points/r.Possible*100)
+	}
+}
+
+// Solves for the minimum score on the ungraded assignment at index that brings the projected grade
+// up to the letter's cutoff. Other ungraded assignments are treated as in WhatIf.
+func (c CourseItem) RequiredScore(index int, letter string, assumePercent *float64) (RequiredScore, error) {
+	if index < 0 || index >= len(c.Assignments) {
+		return RequiredScore{}, errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	target := c.Assignments[index]
+	if target.IsGraded() {
+		return RequiredScore{}, errors.New(AlreadyGradedErrMsg)
+	}
+
+	if !c.countsTowardGrade(target) {
+		return RequiredScore{}, errors.New(UngradableAssignmentErrMsg)
+	}
+
+	cutoff, err := c.cutoffFor(letter)
+	if err != nil {
+		return RequiredScore{}, err
+	}
+
+	// With every other score fixed, the projected grade is linear in this one score
+	possible := *target.PointsPossible
+	low, err := c.WhatIf(map[int]float64{index: 0}, assumePercent)
+	if err != nil {
+		return RequiredScore{}, err
+	}
+
+	high, err := c.WhatIf(map[int]float64{index: possible}, assumePercent)
+	if err != nil {
+		return RequiredScore{}, err
+	}
+
+	slope := (high.Percent - low.Percent) / possible
+	points := (cutoff.Min - low.Percent) / slope
+
+	return RequiredScore{Letter: letter, Points: points, Possible: possible}, nil
+}
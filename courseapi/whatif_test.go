@@ -0,0 +1,125 @@
+package courseapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGradeCutoffs_SortedHighestFirst_Success(t *testing.T) {
+	cutoffs, err := ParseGradeCutoffs([]string{"B=80", "A-=90", "A=93", "F=0"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []LetterCutoff{{"A", 93}, {"A-", 90}, {"B", 80}, {"F", 0}}, cutoffs)
+}
+
+func TestParseGradeCutoffs_Invalid_Failure(t *testing.T) {
+	_, err := ParseGradeCutoffs([]string{"A90"})
+	assert.ErrorContains(t, err, InvalidCutoffErrMsg)
+
+	_, err = ParseGradeCutoffs([]string{"A=101"})
+	assert.ErrorContains(t, err, InvalidCutoffErrMsg)
+
+	for _, arg := range []string{"A=NaN", "A=Inf", "A=-Inf"} {
+		_, err = ParseGradeCutoffs([]string{arg, "B=NaN"})
+		assert.EqualError(t, err, InvalidCutoffErrMsg+": `"+arg+"`")
+	}
+
+	_, err = ParseGradeCutoffs([]string{"A=90", "A=85"})
+	assert.EqualError(t, err, DuplicateCutoffErrMsg)
+}
+
+func TestCourseItem_LetterFor_DefaultAndCustomCutoffs_Success(t *testing.T) {
+	course := CourseItem{Name: "CS101"}
+	assert.Equal(t, "B", course.LetterFor(85))
+	assert.Equal(t, "F", course.LetterFor(12))
+
+	course.GradeCutoffs, _ = ParseGradeCutoffs([]string{"A=93", "A-=90", "B=80"})
+	assert.Equal(t, "A-", course.LetterFor(91))
+	assert.Equal(t, "", course.LetterFor(50))
+}
+
+func TestCourseItem_WhatIf_HypotheticalScore_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 8)
+	course.RecordScore(1, 8)  // Labs 80%
+	course.RecordScore(2, 70) // Exams 70/100
+
+	grade, err := course.WhatIf(map[int]float64{3: 100}, nil)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 83.0, grade.Percent, 0.001) // 0.4*80 + 0.6*85
+
+	// The course itself is untouched
+	assert.False(t, course.Assignments[3].IsGraded())
+}
+
+func TestCourseItem_WhatIf_AssumePercent_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 10)
+
+	assume := 50.0
+	grade, err := course.WhatIf(nil, &assume)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.4*75+0.6*50, grade.Percent, 0.001)
+}
+
+func TestCourseItem_WhatIf_AlreadyGraded_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 10)
+
+	_, err := course.WhatIf(map[int]float64{0: 5}, nil)
+	assert.ErrorContains(t, err, AlreadyGradedErrMsg)
+}
+
+func TestCourseItem_RequiredScore_Final_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 9)
+	course.RecordScore(1, 9)  // Labs 90%
+	course.RecordScore(2, 80) // Exams 80/100
+
+	// 0.4*90 + 0.6*(80+x)/200*100 >= 90  =>  x >= 100
+	required, err := course.RequiredScore(3, "A", nil)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 100.0, required.Points, 0.001)
+	assert.True(t, required.Reachable())
+	assert.False(t, required.Secured())
+
+	// 0.4*90 + 0.6*(80+x)/2 >= 80  =>  x >= 66.67
+	required, err = course.RequiredScore(3, "B", nil)
+	assert.NoError(t, err)
+	assert.InDelta(t, 66.667, required.Points, 0.001)
+	assert.Equal(t, "B needs at least 66.67/100 (66.67%).", required.String())
+}
+
+func TestCourseItem_RequiredScore_UnreachableAndSecured_Success(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 2)
+	course.RecordScore(1, 2)
+	course.RecordScore(2, 40)
+
+	required, err := course.RequiredScore(3, "A", nil)
+	assert.NoError(t, err)
+	assert.False(t, required.Reachable())
+
+	required, err = course.RequiredScore(3, "F", nil)
+	assert.NoError(t, err)
+	assert.True(t, required.Secured())
+}
+
+func TestCourseItem_RequiredScore_Invalid_Failure(t *testing.T) {
+	course := newTestTerm()["CS101"]
+	course.RecordScore(0, 9)
+
+	_, err := course.RequiredScore(0, "A", nil)
+	assert.EqualError(t, err, AlreadyGradedErrMsg)
+
+	_, err = course.RequiredScore(3, "Z", nil)
+	assert.EqualError(t, err, UnknownLetterErrMsg)
+
+	course.Assignments.AddAssignment("Extra", "06/01/25")
+	_, err = course.RequiredScore(4, "A", nil)
+	assert.EqualError(t, err, UngradableAssignmentErrMsg)
+}
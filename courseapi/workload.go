@@ -0,0 +1,171 @@
+package courseapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	InvalidEffortErrMsg       = "estimated effort must be a number of hours greater than 0"
+	InvalidThresholdErrMsg    = "workload threshold must be a number of hours greater than 0"
+	InvalidWorkloadSpanErrMsg = "workload must cover at least 1 day and at most 2 years"
+
+	// Hours per day above which a day is flagged, unless configured otherwise
+	DefaultWorkloadThreshold = 4.0
+
+	// Longest span a workload can cover, which keeps its buckets to a sensible number
+	MaxWorkloadDays = 2 * 366
+
+	// Histogram bars are scaled down so the longest is at most this many characters
+	workloadBarWidth = 40
+)
+
+func (c *CourseItem) SetEffort(index int, hours float64) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	if !isFinite(hours) || hours <= 0 {
+		return errors.New(InvalidEffortErrMsg)
+	}
+
+	c.Assignments[index].EstimatedHours = &hours
+	return nil
+}
+
+func (c *CourseItem) ClearEffort(index int) error {
+	if index < 0 || index >= len(c.Assignments) {
+		return errors.New(InvalidSliceRemoveErrMsg)
+	}
+
+	c.Assignments[index].EstimatedHours = nil
+	return nil
+}
+
+// Effort due within one day or week
+type WorkloadBucket struct {
+	Start time.Time
+	Hours float64
+	// Number of assignments with an estimate that are due in the bucket
+	Assignments int
+	// Days in the bucket over the threshold; at most 1 for a day bucket
+	HeavyDays int
+}
+
+// Estimated effort of ungraded assignments across courses, by the day or week they are due
+type Workload struct {
+	Buckets   []WorkloadBucket
+	ByWeek    bool
+	Threshold float64
+	// Ungraded assignments due in the period that have no estimate, so aren't counted
+	Unestimated int
+}
+
+// Buckets the effort due in the given number of days (or weeks, if byWeek) starting on from.
+// Weeks start on Monday, so the first week bucket may begin before from.
+func NewWorkload(cm CourseMap, from time.Time, count int, byWeek bool, threshold float64) (Workload, error) {
+	if !isFinite(threshold) || threshold <= 0 {
+		return Workload{}, errors.New(InvalidThresholdErrMsg)
+	}
+
+	// Checked before multiplying by 7, so a huge number of weeks can't overflow
+	if count <= 0 || byWeek && count > MaxWorkloadDays/7 || count > MaxWorkloadDays {
+		return Workload{}, errors.New(InvalidWorkloadSpanErrMsg)
+	}
+
+	start := filterToday(from)
+	days := count
+	if byWeek {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		days = count * 7
+	}
+	end := start.AddDate(0, 0, days)
+
+	dayHours := make([]float64, days)
+	dayCounts := make([]int, days)
+	w := Workload{ByWeek: byWeek, Threshold: threshold}
+
+	for _, course := range cm {
+		for _, a := range course.Assignments {
+			if a.IsGraded() || a.DueAt.Before(start) || !a.DueAt.Before(end) {
+				continue
+			}
+
+			if a.EstimatedHours == nil {
+				w.Unestimated++
+				continue
+			}
+
+			day := int(a.DueAt.Sub(start).Hours() / 24)
+			dayHours[day] += *a.EstimatedHours
+			dayCounts[day]++
+		}
+	}
+
+	bucketDays := 1
+	if byWeek {
+		bucketDays = 7
+	}
+
+	for i := 0; i < days; i += bucketDays {
+		bucket := WorkloadBucket{Start: start.AddDate(0, 0, i)}
+		for day := i; day < i+bucketDays; day++ {
+			bucket.Hours += dayHours[day]
+			bucket.Assignments += dayCounts[day]
+			if dayHours[day] > threshold {
+				bucket.HeavyDays++
+			}
+		}
+
+		w.Buckets = append(w.Buckets, bucket)
+	}
+
+	return w, nil
+}
+
+func (w Workload) String() string {
+	maxHours := 0.0
+	for _, b := range w.Buckets {
+		maxHours = math.Max(maxHours, b.Hours)
+	}
+
+	hoursPerChar := 1.0
+	if maxHours > workloadBarWidth {
+		hoursPerChar = maxHours / workloadBarWidth
+	}
+
+	result := ""
+	for _, b := range w.Buckets {
+		label := b.Start.Format("Mon " + DateFormat)
+		if w.ByWeek {
+			label = "Week of " + b.Start.Format(DateFormat)
+		}
+
+		bar := strings.Repeat("#", int(math.Round(b.Hours/hoursPerChar)))
+		line := fmt.Sprintf("%-16s | %-*s %5.1fh", label, workloadBarWidth, bar, b.Hours)
+
+		if w.ByWeek && b.HeavyDays > 0 {
+			line += fmt.Sprintf("  ! %d heavy day(s)", b.HeavyDays)
+		} else if !w.ByWeek && b.HeavyDays > 0 {
+			line += "  ! heavy"
+		}
+
+		result += strings.TrimRight(line, " ") + "\n"
+	}
+
+	if hoursPerChar != 1 {
+		result += fmt.Sprintf("(each # is %.1f hours)\n", hoursPerChar)
+	} else {
+		result += "(each # is 1 hour)\n"
+	}
+	result += fmt.Sprintf("Days over %g hours are flagged as heavy.\n", w.Threshold)
+
+	if w.Unestimated > 0 {
+		result += fmt.Sprintf("%d ungraded assignment(s) in this period have no effort estimate and aren't counted.\n", w.Unestimated)
+	}
+
+	return result
+}
@@ -0,0 +1,125 @@
+package courseapi
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Sunday, 02/09/25
+var workloadTestNow = time.Date(2025, 2, 9, 9, 0, 0, 0, time.UTC)
+
+func TestCourseItem_SetEffort_Failure(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Lab 1", "02/05/25"})
+
+	for _, hours := range []float64{0, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		assert.EqualError(t, course.SetEffort(0, hours), InvalidEffortErrMsg, hours)
+	}
+	assert.Nil(t, course.Assignments[0].EstimatedHours)
+	assert.EqualError(t, course.SetEffort(1, 2), InvalidSliceRemoveErrMsg)
+
+	assert.NoError(t, course.SetEffort(0, 2.5))
+	assert.Contains(t, course.Assignments[0].String(), "\nEffort: 2.5h")
+	assert.NoError(t, course.ClearEffort(0))
+	assert.Nil(t, course.Assignments[0].EstimatedHours)
+}
+
+func TestNewWorkload_ByDay_Success(t *testing.T) {
+	cm := newTestTerm()
+	cm["CS101"].SetEffort(1, 2)   // Lab 2, due 02/09/25
+	cm["MATH200"].SetEffort(1, 4) // Midterm, due 02/12/25
+
+	w, err := NewWorkload(cm, workloadTestNow, 4, false, 3)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, len(w.Buckets))
+	assert.Equal(t, 2.0, w.Buckets[0].Hours)
+	assert.Equal(t, 0, w.Buckets[0].HeavyDays)
+	assert.Equal(t, 0.0, w.Buckets[1].Hours)
+	assert.Equal(t, 4.0, w.Buckets[3].Hours)
+	assert.Equal(t, 1, w.Buckets[3].Assignments)
+	assert.Equal(t, 1, w.Buckets[3].HeavyDays)
+	assert.Equal(t, 0, w.Unestimated)
+}
+
+func TestNewWorkload_ByWeek_StartsMonday_Success(t *testing.T) {
+	cm := newTestTerm()
+	cm["CS101"].SetEffort(1, 2)   // Lab 2, due 02/09/25
+	cm["MATH200"].SetEffort(1, 4) // Midterm, due 02/12/25
+	cm["MATH200"].Assignments.AddAssignment("Quiz", "02/12/25")
+	cm["MATH200"].SetEffort(2, 1.5)
+
+	w, err := NewWorkload(cm, workloadTestNow, 2, true, 5)
+	assert.NoError(t, err)
+
+	// The first week starts on the Monday before today, so it includes the unestimated problem set
+	assert.Equal(t, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), w.Buckets[0].Start)
+	assert.Equal(t, 2.0, w.Buckets[0].Hours)
+	assert.Equal(t, 0, w.Buckets[0].HeavyDays)
+	assert.Equal(t, 5.5, w.Buckets[1].Hours)
+	assert.Equal(t, 2, w.Buckets[1].Assignments)
+	assert.Equal(t, 1, w.Buckets[1].HeavyDays)
+	assert.Equal(t, 1, w.Unestimated)
+}
+
+func TestNewWorkload_SkipsGraded_Success(t *testing.T) {
+	cm := newTestTerm()
+	cm["CS101"].SetEffort(1, 2) // Lab 2, due 02/09/25
+	cm["CS101"].RecordScore(1, 10)
+
+	w, _ := NewWorkload(cm, workloadTestNow, 3, false, 4)
+	assert.Equal(t, 0.0, w.Buckets[0].Hours)
+	assert.Equal(t, 0, w.Unestimated)
+}
+
+func TestNewWorkload_InvalidThreshold_Failure(t *testing.T) {
+	for _, threshold := range []float64{0, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := NewWorkload(CourseMap{}, workloadTestNow, 1, false, threshold)
+		assert.EqualError(t, err, InvalidThresholdErrMsg, threshold)
+	}
+}
+
+func TestNewWorkload_InvalidSpan_Failure(t *testing.T) {
+	for _, span := range []struct {
+		count  int
+		byWeek bool
+	}{{0, false}, {-1, true}, {MaxWorkloadDays + 1, false}, {MaxWorkloadDays/7 + 1, true}, {9999999999999, true}} {
+		_, err := NewWorkload(CourseMap{}, workloadTestNow, span.count, span.byWeek, 4)
+		assert.EqualError(t, err, InvalidWorkloadSpanErrMsg, span)
+	}
+
+	_, err := NewWorkload(CourseMap{}, workloadTestNow, MaxWorkloadDays, false, 4)
+	assert.NoError(t, err)
+}
+
+func TestWorkload_String_Histogram_Success(t *testing.T) {
+	cm := newTestTerm()
+	cm["CS101"].SetEffort(1, 2)   // Lab 2, due 02/09/25
+	cm["MATH200"].SetEffort(1, 4) // Midterm, due 02/12/25
+
+	cm["MATH200"].Assignments.AddAssignment("Quiz", "02/12/25")
+
+	w, _ := NewWorkload(cm, workloadTestNow, 4, false, 3)
+
+	expected := "Sun 02/09/25     | ##                                         2.0h\n" +
+		"Mon 02/10/25     |                                            0.0h\n" +
+		"Tue 02/11/25     |                                            0.0h\n" +
+		"Wed 02/12/25     | ####                                       4.0h  ! heavy\n" +
+		"(each # is 1 hour)\n" +
+		"Days over 3 hours are flagged as heavy.\n" +
+		"1 ungraded assignment(s) in this period have no effort estimate and aren't counted.\n"
+	assert.Equal(t, expected, w.String())
+}
+
+func TestWorkload_String_ScalesLongBars_Success(t *testing.T) {
+	course := newTestCourse("CS101", [2]string{"Thesis", "02/05/25"})
+	course.SetEffort(0, 80)
+
+	w, _ := NewWorkload(CourseMap{"CS101": course}, workloadTestNow, 2, true, 4)
+
+	assert.Contains(t, w.String(), "Week of 02/03/25 | "+strings.Repeat("#", 40)+"  80.0h  ! 1 heavy day(s)\n")
+	assert.Contains(t, w.String(), "(each # is 2.0 hours)\n")
+}